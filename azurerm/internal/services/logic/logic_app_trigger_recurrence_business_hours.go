@@ -0,0 +1,485 @@
+package logic
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+// logicAppTriggerRecurrenceBusinessHoursIDSeparator joins the two underlying trigger
+// IDs that make up a business-hours-aware `azurerm_logic_app_trigger_recurrence`.
+const logicAppTriggerRecurrenceBusinessHoursIDSeparator = "|"
+
+var timeOfDayPattern = regexp.MustCompile(`^([01]\d|2[0-3]):([0-5]\d)$`)
+
+// validateLogicAppTriggerRecurrenceTimeOfDay requires `HH:MM` (24 hour) format, and
+// further requires `MM` to be `00`: expandLogicAppTriggerRecurrenceBusinessHoursWindow
+// only ever constrains a trigger down to `at_these_hours`, so a non-zero minute can't
+// be represented without silently rounding the boundary down to the hour - rejecting
+// it here is better than a business-hours window that's silently up to an hour off.
+func validateLogicAppTriggerRecurrenceTimeOfDay(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+
+	matches := timeOfDayPattern.FindStringSubmatch(v)
+	if matches == nil {
+		errors = append(errors, fmt.Errorf("%q must be in `HH:MM` (24 hour) format, got %q", k, v))
+		return warnings, errors
+	}
+	if matches[2] != "00" {
+		errors = append(errors, fmt.Errorf("%q must be on the hour (`MM` must be `00`) - sub-hour business hours boundaries aren't supported, got %q", k, v))
+	}
+
+	return warnings, errors
+}
+
+// logicAppTriggerRecurrenceBusinessHoursScheduleResource returns the `schedule` block
+// nested under `during_business_hours`/`outside_business_hours`. It's deliberately a
+// smaller, self-contained recurrence definition (frequency/interval plus the usual
+// at_these_hours/at_these_minutes/on_these_days) rather than reusing the top-level
+// `schedule` block, since each half of a business-hours split polls independently.
+func logicAppTriggerRecurrenceBusinessHoursScheduleResource() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"frequency": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						"Second",
+						"Minute",
+						"Hour",
+					}, false),
+				},
+				"interval": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"at_these_hours": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeInt,
+						ValidateFunc: validation.IntBetween(0, 23),
+					},
+				},
+				"at_these_minutes": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeInt,
+						ValidateFunc: validation.IntBetween(0, 59),
+					},
+				},
+				"on_these_days": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+						ValidateFunc: validation.StringInSlice([]string{
+							"Monday",
+							"Tuesday",
+							"Wednesday",
+							"Thursday",
+							"Friday",
+							"Saturday",
+							"Sunday",
+						}, false),
+					},
+				},
+			},
+		},
+	}
+}
+
+func isLogicAppTriggerRecurrenceBusinessHoursID(id string) bool {
+	return strings.Contains(id, logicAppTriggerRecurrenceBusinessHoursIDSeparator)
+}
+
+func resourceLogicAppTriggerRecurrenceBusinessHoursCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	during := d.Get("during_business_hours").([]interface{})
+	outside := d.Get("outside_business_hours").([]interface{})
+	if len(during) != 1 || during[0] == nil || len(outside) != 1 || outside[0] == nil {
+		return fmt.Errorf("both `during_business_hours` and `outside_business_hours` must be specified")
+	}
+
+	duringAttrs := during[0].(map[string]interface{})
+	duringSchedule := duringAttrs["schedule"].([]interface{})
+	outsideSchedule := outside[0].(map[string]interface{})["schedule"].([]interface{})
+
+	window, err := expandLogicAppTriggerRecurrenceBusinessHoursWindow(duringAttrs)
+	if err != nil {
+		return fmt.Errorf("in `during_business_hours`: %+v", err)
+	}
+
+	duringFrequency, duringInterval, duringRecurrenceSchedule, err := expandLogicAppTriggerRecurrenceBusinessHoursSchedule(duringSchedule, window.businessDays, window.businessHours)
+	if err != nil {
+		return fmt.Errorf("in `during_business_hours.schedule`: %+v", err)
+	}
+	outsideFrequency, outsideInterval, outsideRecurrenceSchedule, err := expandLogicAppTriggerRecurrenceBusinessHoursSchedule(outsideSchedule, nil, window.outsideHours)
+	if err != nil {
+		return fmt.Errorf("in `outside_business_hours.schedule`: %+v", err)
+	}
+
+	logicAppId := d.Get("logic_app_id").(string)
+	name := d.Get("name").(string)
+	timeZone := d.Get("time_zone").(string)
+
+	duringTriggerName := fmt.Sprintf("%s-business-hours", name)
+	outsideTriggerName := fmt.Sprintf("%s-after-hours", name)
+
+	duringTrigger := map[string]interface{}{
+		"recurrence": map[string]interface{}{
+			"frequency": duringFrequency,
+			"interval":  duringInterval,
+			"schedule":  duringRecurrenceSchedule,
+		},
+		"type": "Recurrence",
+	}
+	outsideTrigger := map[string]interface{}{
+		"recurrence": map[string]interface{}{
+			"frequency": outsideFrequency,
+			"interval":  outsideInterval,
+			"schedule":  outsideRecurrenceSchedule,
+		},
+		"type": "Recurrence",
+	}
+	if timeZone != "" {
+		duringTrigger["recurrence"].(map[string]interface{})["timeZone"] = normalizeLogicAppTriggerRecurrenceTimeZone(timeZone)
+		outsideTrigger["recurrence"].(map[string]interface{})["timeZone"] = normalizeLogicAppTriggerRecurrenceTimeZone(timeZone)
+	}
+
+	if err := resourceLogicAppTriggerUpdate(d, meta, logicAppId, duringTriggerName, duringTrigger, "azurerm_logic_app_trigger_recurrence"); err != nil {
+		return fmt.Errorf("creating the `during_business_hours` Trigger: %+v", err)
+	}
+	duringId := d.Id()
+
+	if err := resourceLogicAppTriggerUpdate(d, meta, logicAppId, outsideTriggerName, outsideTrigger, "azurerm_logic_app_trigger_recurrence"); err != nil {
+		return fmt.Errorf("creating the `outside_business_hours` Trigger: %+v", err)
+	}
+	outsideId := d.Id()
+
+	d.SetId(strings.Join([]string{duringId, outsideId}, logicAppTriggerRecurrenceBusinessHoursIDSeparator))
+	d.Set("outside_business_hours_trigger_name", outsideTriggerName)
+
+	return resourceLogicAppTriggerRecurrenceBusinessHoursRead(d, meta)
+}
+
+func resourceLogicAppTriggerRecurrenceBusinessHoursRead(d *schema.ResourceData, meta interface{}) error {
+	ids := strings.SplitN(d.Id(), logicAppTriggerRecurrenceBusinessHoursIDSeparator, 2)
+	if len(ids) != 2 {
+		return fmt.Errorf("expected a composite ID of the form `<during-id>%s<outside-id>`, got %q", logicAppTriggerRecurrenceBusinessHoursIDSeparator, d.Id())
+	}
+
+	duringId, err := azure.ParseAzureResourceID(ids[0])
+	if err != nil {
+		return err
+	}
+	outsideId, err := azure.ParseAzureResourceID(ids[1])
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := duringId.ResourceGroup
+	logicAppName := duringId.Path["workflows"]
+	duringTriggerName := duringId.Path["triggers"]
+	outsideTriggerName := outsideId.Path["triggers"]
+
+	duringTrigger, app, err := retrieveLogicAppTrigger(d, meta, resourceGroup, logicAppName, duringTriggerName)
+	if err != nil {
+		return err
+	}
+	outsideTrigger, _, err := retrieveLogicAppTrigger(d, meta, resourceGroup, logicAppName, outsideTriggerName)
+	if err != nil {
+		return err
+	}
+
+	if duringTrigger == nil || outsideTrigger == nil {
+		log.Printf("[DEBUG] Logic App %q (Resource Group %q) is missing one of the business-hours Triggers - removing from state", logicAppName, resourceGroup)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", strings.TrimSuffix(duringTriggerName, "-business-hours"))
+	d.Set("logic_app_id", app.ID)
+	d.Set("outside_business_hours_trigger_name", outsideTriggerName)
+
+	duringRecurrence, ok := (*duringTrigger)["recurrence"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("`recurrence` was missing/invalid on Trigger %q", duringTriggerName)
+	}
+	outsideRecurrence, ok := (*outsideTrigger)["recurrence"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("`recurrence` was missing/invalid on Trigger %q", outsideTriggerName)
+	}
+
+	if timeZone := duringRecurrence["timeZone"]; timeZone != nil {
+		d.Set("time_zone", coerceLogicAppTriggerRecurrenceTimeZone(timeZone.(string), d.Get("time_zone").(string)))
+	}
+
+	d.Set("during_business_hours", flattenLogicAppTriggerRecurrenceBusinessHoursBlock(d.Get("during_business_hours").([]interface{}), duringRecurrence))
+	d.Set("outside_business_hours", []interface{}{
+		map[string]interface{}{
+			"schedule": flattenLogicAppTriggerRecurrenceBusinessHoursSchedule(outsideRecurrence),
+		},
+	})
+
+	return nil
+}
+
+func resourceLogicAppTriggerRecurrenceBusinessHoursDelete(d *schema.ResourceData, meta interface{}) error {
+	ids := strings.SplitN(d.Id(), logicAppTriggerRecurrenceBusinessHoursIDSeparator, 2)
+	if len(ids) != 2 {
+		return fmt.Errorf("expected a composite ID of the form `<during-id>%s<outside-id>`, got %q", logicAppTriggerRecurrenceBusinessHoursIDSeparator, d.Id())
+	}
+
+	duringId, err := azure.ParseAzureResourceID(ids[0])
+	if err != nil {
+		return err
+	}
+	outsideId, err := azure.ParseAzureResourceID(ids[1])
+	if err != nil {
+		return err
+	}
+
+	if err := resourceLogicAppTriggerRemove(d, meta, duringId.ResourceGroup, duringId.Path["workflows"], duringId.Path["triggers"]); err != nil {
+		return fmt.Errorf("Error removing Trigger %q from Logic App %q (Resource Group %q): %+v", duringId.Path["triggers"], duringId.Path["workflows"], duringId.ResourceGroup, err)
+	}
+	if err := resourceLogicAppTriggerRemove(d, meta, outsideId.ResourceGroup, outsideId.Path["workflows"], outsideId.Path["triggers"]); err != nil {
+		return fmt.Errorf("Error removing Trigger %q from Logic App %q (Resource Group %q): %+v", outsideId.Path["triggers"], outsideId.Path["workflows"], outsideId.ResourceGroup, err)
+	}
+
+	return nil
+}
+
+// logicAppTriggerRecurrenceBusinessHoursWindow is the business-hours window implied by
+// `during_business_hours.start_time_of_day`/`end_time_of_day`/`days_of_week`, resolved
+// down to the hour-of-day granularity that `at_these_hours` understands.
+type logicAppTriggerRecurrenceBusinessHoursWindow struct {
+	businessDays  []string
+	businessHours []int
+	outsideHours  []int
+}
+
+// expandLogicAppTriggerRecurrenceBusinessHoursWindow folds `start_time_of_day`,
+// `end_time_of_day` and `days_of_week` into the hour/day sets that actually constrain
+// the "during" trigger, plus the complementary hour set for the "outside" trigger.
+// Business hours are required to fall within a single day (they don't wrap past
+// midnight), since that's the only shape a plain hour-of-day window can express.
+func expandLogicAppTriggerRecurrenceBusinessHoursWindow(duringAttrs map[string]interface{}) (*logicAppTriggerRecurrenceBusinessHoursWindow, error) {
+	startHour, err := logicAppTriggerRecurrenceTimeOfDayHour(duringAttrs["start_time_of_day"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("`start_time_of_day`: %+v", err)
+	}
+	endHour, err := logicAppTriggerRecurrenceTimeOfDayHour(duringAttrs["end_time_of_day"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("`end_time_of_day`: %+v", err)
+	}
+	if endHour <= startHour {
+		return nil, fmt.Errorf("`end_time_of_day` must be later than `start_time_of_day` within the same day")
+	}
+
+	businessHours := make([]int, 0, endHour-startHour)
+	for hour := startHour; hour < endHour; hour++ {
+		businessHours = append(businessHours, hour)
+	}
+
+	inBusinessHours := make(map[int]bool, len(businessHours))
+	for _, hour := range businessHours {
+		inBusinessHours[hour] = true
+	}
+	outsideHours := make([]int, 0, 24-len(businessHours))
+	for hour := 0; hour < 24; hour++ {
+		if !inBusinessHours[hour] {
+			outsideHours = append(outsideHours, hour)
+		}
+	}
+
+	daysRaw := duringAttrs["days_of_week"].(*schema.Set).List()
+	businessDays := make([]string, 0, len(daysRaw))
+	for _, day := range daysRaw {
+		businessDays = append(businessDays, day.(string))
+	}
+
+	return &logicAppTriggerRecurrenceBusinessHoursWindow{
+		businessDays:  businessDays,
+		businessHours: businessHours,
+		outsideHours:  outsideHours,
+	}, nil
+}
+
+// logicAppTriggerRecurrenceTimeOfDayHour returns the hour component of an `HH:MM`
+// time of day. It's only ever called on values already accepted by
+// validateLogicAppTriggerRecurrenceTimeOfDay, which guarantees `MM` is `00`, so the
+// minute component is deliberately not read here.
+func logicAppTriggerRecurrenceTimeOfDayHour(input string) (int, error) {
+	matches := timeOfDayPattern.FindStringSubmatch(input)
+	if matches == nil {
+		return 0, fmt.Errorf("%q must be in `HH:MM` (24 hour) format", input)
+	}
+	hour, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, err
+	}
+	return hour, nil
+}
+
+// expandLogicAppTriggerRecurrenceBusinessHoursSchedule validates and translates one
+// `during_business_hours.schedule`/`outside_business_hours.schedule` block into the
+// frequency/interval/schedule pieces of a Logic App recurrence payload.
+//
+// `forcedDays`/`forcedHours` constrain the block to the business-hours window computed
+// by expandLogicAppTriggerRecurrenceBusinessHoursWindow: for the "during" trigger
+// they're the business days/hours themselves, for the "outside" trigger `forcedDays`
+// is nil (it runs every day of the week) and `forcedHours` is the complementary hour
+// set. Without this, `at_these_hours`/`on_these_days` in the nested `schedule` block
+// would be the only thing that ever reached the trigger, and the business-hours window
+// itself would have no effect on when either trigger actually fires.
+func expandLogicAppTriggerRecurrenceBusinessHoursSchedule(input []interface{}, forcedDays []string, forcedHours []int) (string, int, map[string]interface{}, error) {
+	if len(input) != 1 || input[0] == nil {
+		return "", 0, nil, fmt.Errorf("`schedule` is required")
+	}
+
+	attrs := input[0].(map[string]interface{})
+	frequency := attrs["frequency"].(string)
+	interval := attrs["interval"].(int)
+
+	if frequency == "Day" || frequency == "Week" || frequency == "Month" {
+		return "", 0, nil, fmt.Errorf("`frequency` must be `Hour`, `Minute` or `Second` - %q is too coarse to usefully differ between business hours and off hours", frequency)
+	}
+
+	hours, err := logicAppTriggerRecurrenceConstrainInts(attrs["at_these_hours"].(*schema.Set).List(), forcedHours, "at_these_hours")
+	if err != nil {
+		return "", 0, nil, err
+	}
+	days, err := logicAppTriggerRecurrenceConstrainStrings(attrs["on_these_days"].(*schema.Set).List(), forcedDays, "on_these_days")
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	schedule := expandLogicAppTriggerRecurrenceSchedule([]interface{}{
+		map[string]interface{}{
+			"at_these_hours":   schema.NewSet(schema.HashInt, hours),
+			"at_these_minutes": attrs["at_these_minutes"],
+			"on_these_days":    schema.NewSet(schema.HashString, days),
+		},
+	})
+
+	return frequency, interval, schedule, nil
+}
+
+// logicAppTriggerRecurrenceConstrainInts intersects `declared` (as set by the user in
+// `schedule`) with `forced` (the business-hours window), falling back to `forced`
+// entirely when the user didn't declare anything. An empty intersection means the
+// user's own `schedule` has no overlap with the business-hours window, which would
+// otherwise silently produce a trigger that never fires.
+func logicAppTriggerRecurrenceConstrainInts(declared []interface{}, forced []int, fieldName string) ([]interface{}, error) {
+	if forced == nil {
+		return declared, nil
+	}
+	if len(declared) == 0 {
+		result := make([]interface{}, len(forced))
+		for i, v := range forced {
+			result[i] = v
+		}
+		return result, nil
+	}
+
+	allowed := make(map[int]bool, len(forced))
+	for _, v := range forced {
+		allowed[v] = true
+	}
+	result := make([]interface{}, 0, len(declared))
+	for _, v := range declared {
+		if allowed[v.(int)] {
+			result = append(result, v)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("`%s` has no overlap with the business-hours window", fieldName)
+	}
+	return result, nil
+}
+
+// logicAppTriggerRecurrenceConstrainStrings is the `on_these_days`/`days_of_week`
+// analogue of logicAppTriggerRecurrenceConstrainInts. A nil `forced` (used for the
+// "outside" trigger's days, which run every day of the week) leaves `declared` as-is.
+func logicAppTriggerRecurrenceConstrainStrings(declared []interface{}, forced []string, fieldName string) ([]interface{}, error) {
+	if forced == nil {
+		return declared, nil
+	}
+	if len(declared) == 0 {
+		result := make([]interface{}, len(forced))
+		for i, v := range forced {
+			result[i] = v
+		}
+		return result, nil
+	}
+
+	allowed := make(map[string]bool, len(forced))
+	for _, v := range forced {
+		allowed[v] = true
+	}
+	result := make([]interface{}, 0, len(declared))
+	for _, v := range declared {
+		if allowed[v.(string)] {
+			result = append(result, v)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("`%s` has no overlap with the business-hours window", fieldName)
+	}
+	return result, nil
+}
+
+func flattenLogicAppTriggerRecurrenceBusinessHoursSchedule(recurrence map[string]interface{}) []interface{} {
+	attrs := map[string]interface{}{}
+	if frequency := recurrence["frequency"]; frequency != nil {
+		attrs["frequency"] = frequency.(string)
+	}
+	if interval := recurrence["interval"]; interval != nil {
+		attrs["interval"] = int(interval.(float64))
+	}
+
+	if schedule, ok := recurrence["schedule"].(map[string]interface{}); ok {
+		flattened := flattenLogicAppTriggerRecurrenceSchedule(schedule)[0].(map[string]interface{})
+		attrs["at_these_hours"] = flattened["at_these_hours"]
+		attrs["at_these_minutes"] = flattened["at_these_minutes"]
+		attrs["on_these_days"] = flattened["on_these_days"]
+	}
+
+	return []interface{}{attrs}
+}
+
+func flattenLogicAppTriggerRecurrenceBusinessHoursBlock(existing []interface{}, recurrence map[string]interface{}) []interface{} {
+	attrs := map[string]interface{}{
+		"schedule": flattenLogicAppTriggerRecurrenceBusinessHoursSchedule(recurrence),
+	}
+
+	// start_time_of_day/end_time_of_day/days_of_week aren't represented in the
+	// underlying triggers directly - they're folded into each trigger's `schedule` at
+	// create/update time (see expandLogicAppTriggerRecurrenceBusinessHoursWindow) and
+	// can't be recovered from the trigger payload alone, so preserve whatever is
+	// already in state.
+	if len(existing) == 1 && existing[0] != nil {
+		existingAttrs := existing[0].(map[string]interface{})
+		attrs["start_time_of_day"] = existingAttrs["start_time_of_day"]
+		attrs["end_time_of_day"] = existingAttrs["end_time_of_day"]
+		attrs["days_of_week"] = existingAttrs["days_of_week"]
+	}
+
+	return []interface{}{attrs}
+}
@@ -0,0 +1,51 @@
+package logic
+
+import "testing"
+
+func TestValidateLogicAppTriggerRecurrenceTimeOfDay(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		wantErrs bool
+	}{
+		{name: "on the hour", input: "09:00", wantErrs: false},
+		{name: "midnight", input: "00:00", wantErrs: false},
+		{name: "last hour of the day", input: "23:00", wantErrs: false},
+		{name: "not HH:MM format", input: "9am", wantErrs: true},
+		{name: "hour out of range", input: "24:00", wantErrs: true},
+		{name: "non-zero minutes are rejected, not rounded down", input: "09:30", wantErrs: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errs := validateLogicAppTriggerRecurrenceTimeOfDay(tc.input, "start_time_of_day")
+			if tc.wantErrs && len(errs) == 0 {
+				t.Fatalf("expected an error for %q, got none", tc.input)
+			}
+			if !tc.wantErrs && len(errs) != 0 {
+				t.Fatalf("expected no error for %q, got %v", tc.input, errs)
+			}
+		})
+	}
+}
+
+func TestLogicAppTriggerRecurrenceTimeOfDayHour(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected int
+	}{
+		{input: "00:00", expected: 0},
+		{input: "09:00", expected: 9},
+		{input: "23:00", expected: 23},
+	}
+
+	for _, tc := range cases {
+		actual, err := logicAppTriggerRecurrenceTimeOfDayHour(tc.input)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", tc.input, err)
+		}
+		if actual != tc.expected {
+			t.Errorf("parsing %q: expected %d, got %d", tc.input, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package logic
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+// resourceLogicAppTriggerRecurrenceMaintenanceWindow attaches one or more suppression
+// windows to an existing `azurerm_logic_app_trigger_recurrence` trigger.
+//
+// The Logic Apps ARM API has no native concept of a maintenance window, so this is
+// implemented by patching the target trigger's `recurrence.schedule` on create/update
+// so each of the trigger's firings inside the window is listed in `exceptions` (for
+// `skip`) or `excludedDates` (for `disable`), and reverting that patch on delete. Each
+// window's own contribution is tracked under `recurrence.schedule.maintenanceWindows`
+// (keyed by this resource's `name`), so multiple windows can safely target the same
+// trigger - see applyLogicAppTriggerRecurrenceMaintenanceWindow.
+func resourceLogicAppTriggerRecurrenceMaintenanceWindow() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLogicAppTriggerRecurrenceMaintenanceWindowCreateUpdate,
+		Read:   resourceLogicAppTriggerRecurrenceMaintenanceWindowRead,
+		Update: resourceLogicAppTriggerRecurrenceMaintenanceWindowCreateUpdate,
+		Delete: resourceLogicAppTriggerRecurrenceMaintenanceWindowDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"logic_app_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"trigger_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"skip",
+					"disable",
+				}, false),
+			},
+
+			"schedule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start_time": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"end_time": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"rrule": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateLogicAppTriggerRecurrenceRRule,
+						},
+						"time_zone": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateLogicAppTriggerRecurrenceTimeZone(),
+						},
+					},
+				},
+			},
+
+			"expand_occurrences_for_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      90,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceLogicAppTriggerRecurrenceMaintenanceWindowCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	logicAppId := d.Get("logic_app_id").(string)
+	triggerName := d.Get("trigger_name").(string)
+	name := d.Get("name").(string)
+
+	id, err := azure.ParseAzureResourceID(logicAppId)
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	logicAppName := id.Path["workflows"]
+
+	t, app, err := retrieveLogicAppTrigger(d, meta, resourceGroup, logicAppName, triggerName)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return fmt.Errorf("Trigger %q was not found in Logic App %q (Resource Group %q)", triggerName, logicAppName, resourceGroup)
+	}
+	trigger := *t
+
+	recurrence, ok := trigger["recurrence"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("`azurerm_logic_app_trigger_recurrence_maintenance_window` can only target a Recurrence trigger, but %q was not one", triggerName)
+	}
+
+	window := expandLogicAppTriggerRecurrenceMaintenanceWindowSchedule(d.Get("schedule").([]interface{}))
+	action := d.Get("action").(string)
+	expandDays := d.Get("expand_occurrences_for_days").(int)
+
+	// apply onto the trigger's current live recurrence (which may already carry other
+	// maintenance windows' exclusions) and only ever touch this window's own entry, so
+	// multiple windows can safely target the same trigger regardless of apply order -
+	// see applyLogicAppTriggerRecurrenceMaintenanceWindow.
+	excluded, err := applyLogicAppTriggerRecurrenceMaintenanceWindow(recurrence, name, window, action, expandDays)
+	if err != nil {
+		return fmt.Errorf("computing maintenance window exclusions: %+v", err)
+	}
+
+	trigger["recurrence"] = excluded
+	if err := resourceLogicAppTriggerUpdate(d, meta, logicAppId, triggerName, trigger, "azurerm_logic_app_trigger_recurrence_maintenance_window"); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/triggers/%s/maintenanceWindows/%s", app.ID, triggerName, name))
+
+	return resourceLogicAppTriggerRecurrenceMaintenanceWindowRead(d, meta)
+}
+
+func resourceLogicAppTriggerRecurrenceMaintenanceWindowRead(d *schema.ResourceData, meta interface{}) error {
+	id, err := azure.ParseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := id.ResourceGroup
+	logicAppName := id.Path["workflows"]
+	triggerName := id.Path["triggers"]
+	name := id.Path["maintenanceWindows"]
+
+	t, app, err := retrieveLogicAppTrigger(d, meta, resourceGroup, logicAppName, triggerName)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		log.Printf("[DEBUG] Trigger %q (Logic App %q / Resource Group %q) was not found - removing Maintenance Window from state", triggerName, logicAppName, resourceGroup)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("logic_app_id", app.ID)
+	d.Set("trigger_name", triggerName)
+
+	window := expandLogicAppTriggerRecurrenceMaintenanceWindowSchedule(d.Get("schedule").([]interface{}))
+	status := "inactive"
+	if isLogicAppTriggerRecurrenceMaintenanceWindowActive(window, time.Now()) {
+		status = "active"
+	}
+	d.Set("status", status)
+
+	return nil
+}
+
+func resourceLogicAppTriggerRecurrenceMaintenanceWindowDelete(d *schema.ResourceData, meta interface{}) error {
+	id, err := azure.ParseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := id.ResourceGroup
+	logicAppName := id.Path["workflows"]
+	triggerName := id.Path["triggers"]
+	name := id.Path["maintenanceWindows"]
+
+	t, app, err := retrieveLogicAppTrigger(d, meta, resourceGroup, logicAppName, triggerName)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		// trigger's already gone - nothing to revert
+		return nil
+	}
+	trigger := *t
+
+	recurrence, ok := trigger["recurrence"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	// only ever remove this window's own contribution - other maintenance windows
+	// sharing the same trigger keep theirs regardless of destroy order, see
+	// removeLogicAppTriggerRecurrenceMaintenanceWindow.
+	trigger["recurrence"] = removeLogicAppTriggerRecurrenceMaintenanceWindow(recurrence, name)
+	if err := resourceLogicAppTriggerUpdate(d, meta, app.ID, triggerName, trigger, "azurerm_logic_app_trigger_recurrence_maintenance_window"); err != nil {
+		return fmt.Errorf("reverting Trigger %q (Logic App %q / Resource Group %q) to its pre-maintenance-window recurrence: %+v", triggerName, logicAppName, resourceGroup, err)
+	}
+
+	return nil
+}
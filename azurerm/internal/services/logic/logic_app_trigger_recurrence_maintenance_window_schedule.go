@@ -0,0 +1,409 @@
+package logic
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type logicAppTriggerRecurrenceMaintenanceWindow struct {
+	startTime string
+	endTime   string
+	rrule     string
+	timeZone  string
+}
+
+// logicAppTriggerRecurrenceCadence is the frequency/interval the *target* trigger
+// itself fires at, as opposed to `logicAppTriggerRecurrenceMaintenanceWindow.rrule`,
+// which describes how often the maintenance window recurs. It's needed to know every
+// instant the trigger actually fires inside a given window, rather than just the
+// window's own start instant.
+type logicAppTriggerRecurrenceCadence struct {
+	frequency string
+	interval  int
+}
+
+func logicAppTriggerRecurrenceCadenceFromRecurrence(recurrence map[string]interface{}) logicAppTriggerRecurrenceCadence {
+	frequency, _ := recurrence["frequency"].(string)
+
+	interval := 1
+	switch v := recurrence["interval"].(type) {
+	case int:
+		interval = v
+	case float64:
+		interval = int(v)
+	}
+
+	return logicAppTriggerRecurrenceCadence{frequency: frequency, interval: interval}
+}
+
+func expandLogicAppTriggerRecurrenceMaintenanceWindowSchedule(input []interface{}) logicAppTriggerRecurrenceMaintenanceWindow {
+	if len(input) == 0 || input[0] == nil {
+		return logicAppTriggerRecurrenceMaintenanceWindow{}
+	}
+
+	attrs := input[0].(map[string]interface{})
+	return logicAppTriggerRecurrenceMaintenanceWindow{
+		startTime: attrs["start_time"].(string),
+		endTime:   attrs["end_time"].(string),
+		rrule:     attrs["rrule"].(string),
+		timeZone:  attrs["time_zone"].(string),
+	}
+}
+
+// logicAppTriggerRecurrenceMaintenanceWindowsKey is the `recurrence.schedule` field
+// used to track each maintenance window's own contribution to `exceptions`/
+// `excludedDates`, keyed by the `azurerm_logic_app_trigger_recurrence_maintenance_window`
+// resource's `name`. Keeping this breakdown (rather than only ever storing the merged
+// `exceptions`/`excludedDates` lists) is what lets multiple maintenance windows target
+// the same trigger safely: each window only ever reads and rewrites its own entry, so
+// creating, updating or deleting one window can't resurrect or erase another's
+// exclusions regardless of apply/destroy order.
+const logicAppTriggerRecurrenceMaintenanceWindowsKey = "maintenanceWindows"
+
+// applyLogicAppTriggerRecurrenceMaintenanceWindow returns a copy of `recurrence` with
+// the given window's exclusions set (replacing any previous exclusions contributed by
+// a window of the same `name`, and leaving every other window's exclusions as-is). For
+// a `skip` action the window's occurrences end up in `exceptions` (the occurrence
+// still triggers, but any action gated on exceptions in the downstream workflow is
+// skipped) - for a `disable` action they end up in `recurrence.schedule.excludedDates`,
+// which stops the trigger firing for those instants altogether.
+//
+// A one-off window (no `rrule`) has every one of the trigger's own firings between
+// `start_time` and `end_time` excluded, not just the instant at `start_time` - a
+// window is a span, and every firing inside that span needs suppressing. A recurring
+// window has that same per-occurrence expansion repeated for its next `expandDays`
+// worth of occurrences, since the Logic Apps recurrence payload has no way to express
+// a recurring exclusion.
+func applyLogicAppTriggerRecurrenceMaintenanceWindow(recurrence map[string]interface{}, name string, window logicAppTriggerRecurrenceMaintenanceWindow, action string, expandDays int) (map[string]interface{}, error) {
+	cadence := logicAppTriggerRecurrenceCadenceFromRecurrence(recurrence)
+	occurrences, err := expandLogicAppTriggerRecurrenceMaintenanceWindowOccurrences(window, expandDays, cadence)
+	if err != nil {
+		return nil, err
+	}
+
+	output, schedule, windows := cloneLogicAppTriggerRecurrenceMaintenanceWindows(recurrence)
+	windows[name] = map[string]interface{}{
+		"action":      action,
+		"occurrences": occurrences,
+	}
+
+	return rebuildLogicAppTriggerRecurrenceMaintenanceWindows(output, schedule, windows), nil
+}
+
+// removeLogicAppTriggerRecurrenceMaintenanceWindow is the inverse of
+// applyLogicAppTriggerRecurrenceMaintenanceWindow: it drops the named window's own
+// contribution and rebuilds `exceptions`/`excludedDates` from whatever windows remain,
+// without disturbing any other window sharing the same trigger.
+func removeLogicAppTriggerRecurrenceMaintenanceWindow(recurrence map[string]interface{}, name string) map[string]interface{} {
+	output, schedule, windows := cloneLogicAppTriggerRecurrenceMaintenanceWindows(recurrence)
+	delete(windows, name)
+
+	return rebuildLogicAppTriggerRecurrenceMaintenanceWindows(output, schedule, windows)
+}
+
+func cloneLogicAppTriggerRecurrenceMaintenanceWindows(recurrence map[string]interface{}) (map[string]interface{}, map[string]interface{}, map[string]interface{}) {
+	output := make(map[string]interface{}, len(recurrence))
+	for k, v := range recurrence {
+		output[k] = v
+	}
+
+	schedule, ok := output["schedule"].(map[string]interface{})
+	if !ok {
+		schedule = map[string]interface{}{}
+	} else {
+		cloned := make(map[string]interface{}, len(schedule))
+		for k, v := range schedule {
+			cloned[k] = v
+		}
+		schedule = cloned
+	}
+
+	windows, ok := schedule[logicAppTriggerRecurrenceMaintenanceWindowsKey].(map[string]interface{})
+	if !ok {
+		windows = map[string]interface{}{}
+	} else {
+		cloned := make(map[string]interface{}, len(windows))
+		for k, v := range windows {
+			cloned[k] = v
+		}
+		windows = cloned
+	}
+
+	return output, schedule, windows
+}
+
+func rebuildLogicAppTriggerRecurrenceMaintenanceWindows(output, schedule, windows map[string]interface{}) map[string]interface{} {
+	names := make([]string, 0, len(windows))
+	for name := range windows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	exceptions := make([]string, 0)
+	excludedDates := make([]string, 0)
+
+	for _, name := range names {
+		entry, ok := windows[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		occurrences := logicAppTriggerRecurrenceStringSlice(entry["occurrences"])
+		if action, _ := entry["action"].(string); action == "disable" {
+			excludedDates = append(excludedDates, occurrences...)
+		} else {
+			exceptions = append(exceptions, occurrences...)
+		}
+	}
+
+	if len(windows) == 0 {
+		delete(schedule, logicAppTriggerRecurrenceMaintenanceWindowsKey)
+	} else {
+		schedule[logicAppTriggerRecurrenceMaintenanceWindowsKey] = windows
+	}
+	if len(exceptions) == 0 {
+		delete(schedule, "exceptions")
+	} else {
+		schedule["exceptions"] = exceptions
+	}
+	if len(excludedDates) == 0 {
+		delete(schedule, "excludedDates")
+	} else {
+		schedule["excludedDates"] = excludedDates
+	}
+
+	output["schedule"] = schedule
+	return output
+}
+
+// logicAppTriggerRecurrenceStringSlice normalizes a []string (as constructed locally)
+// or a []interface{} of strings (as produced by decoding the trigger back from JSON)
+// into a plain []string.
+func logicAppTriggerRecurrenceStringSlice(input interface{}) []string {
+	switch v := input.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, raw := range v {
+			if s, ok := raw.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// isLogicAppTriggerRecurrenceMaintenanceWindowActive reports whether `at` falls inside
+// an occurrence of `window`. For a one-off window that's just the literal
+// `[start_time, end_time)` range. For a recurring window without a `time_zone` it's
+// computed algebraically from the RRULE's period rather than by expanding occurrences,
+// so it stays correct indefinitely rather than only for the first
+// `expand_occurrences_for_days` window. A `time_zone` breaks that algebraic shortcut
+// for the calendar-based frequencies (Day/Week/Month), since a fixed-duration period
+// doesn't land on the same wall-clock time across a DST transition - those step
+// occurrence-by-occurrence from `start` instead (bounded by maxLogicAppTriggerRecurrenceMaintenanceWindowSteps),
+// falling back to the algebraic check if that bound is hit.
+func isLogicAppTriggerRecurrenceMaintenanceWindowActive(window logicAppTriggerRecurrenceMaintenanceWindow, at time.Time) bool {
+	start, err := time.Parse(time.RFC3339, window.startTime)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(time.RFC3339, window.endTime)
+	if err != nil {
+		return false
+	}
+	if !end.After(start) {
+		return false
+	}
+	duration := end.Sub(start)
+
+	if window.rrule == "" {
+		return (at.Equal(start) || at.After(start)) && at.Before(end)
+	}
+
+	expanded, err := expandLogicAppTriggerRecurrenceRRule(window.rrule)
+	if err != nil {
+		return false
+	}
+	period := logicAppFrequencyToDuration(expanded.frequency, expanded.interval)
+	if period <= 0 || at.Before(start) {
+		return false
+	}
+
+	loc, err := resolveLogicAppTriggerRecurrenceTimeZoneLocation(window.timeZone)
+	if err != nil {
+		return false
+	}
+	if loc != nil {
+		switch expanded.frequency {
+		case "Day", "Week", "Month":
+			occurrence, ok := logicAppTriggerRecurrenceLatestOccurrenceOnOrBefore(start, at, expanded.frequency, expanded.interval, loc)
+			if ok {
+				return (at.Equal(occurrence) || at.After(occurrence)) && at.Before(occurrence.Add(duration))
+			}
+			// the bounded walk gave up - fall through to the (DST-naive) algebraic check
+			// rather than reporting `inactive` outright.
+		}
+	}
+
+	elapsed := at.Sub(start) % period
+	return elapsed < duration
+}
+
+// maxLogicAppTriggerRecurrenceMaintenanceWindowSteps bounds the occurrence-by-occurrence
+// walk used to evaluate a `time_zone`-aware recurring window, so a trigger that's been
+// live for years on a daily cadence still resolves in a handful of milliseconds while a
+// pathological gap between `start_time` and `at` can't hang a `terraform plan`.
+const maxLogicAppTriggerRecurrenceMaintenanceWindowSteps = 100000
+
+// logicAppTriggerRecurrenceLatestOccurrenceOnOrBefore walks forward from `start` one
+// occurrence at a time and returns the latest occurrence start that is not after `at`.
+// The second return value is false if the walk exceeded
+// maxLogicAppTriggerRecurrenceMaintenanceWindowSteps before reaching `at`.
+func logicAppTriggerRecurrenceLatestOccurrenceOnOrBefore(start, at time.Time, frequency string, interval int, loc *time.Location) (time.Time, bool) {
+	occurrence := start
+	for i := 0; i < maxLogicAppTriggerRecurrenceMaintenanceWindowSteps; i++ {
+		next := logicAppTriggerRecurrenceStepOccurrence(occurrence, frequency, interval, loc)
+		if next.After(at) {
+			return occurrence, true
+		}
+		occurrence = next
+	}
+	return time.Time{}, false
+}
+
+func expandLogicAppTriggerRecurrenceMaintenanceWindowOccurrences(window logicAppTriggerRecurrenceMaintenanceWindow, expandDays int, cadence logicAppTriggerRecurrenceCadence) ([]string, error) {
+	start, err := time.Parse(time.RFC3339, window.startTime)
+	if err != nil {
+		return nil, fmt.Errorf("parsing `start_time`: %+v", err)
+	}
+	end, err := time.Parse(time.RFC3339, window.endTime)
+	if err != nil {
+		return nil, fmt.Errorf("parsing `end_time`: %+v", err)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("`end_time` must be after `start_time`")
+	}
+	duration := end.Sub(start)
+
+	loc, err := resolveLogicAppTriggerRecurrenceTimeZoneLocation(window.timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("resolving `time_zone`: %+v", err)
+	}
+
+	windowStarts := []time.Time{start}
+	if window.rrule != "" {
+		expanded, err := expandLogicAppTriggerRecurrenceRRule(window.rrule)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `rrule`: %+v", err)
+		}
+		if logicAppFrequencyToDuration(expanded.frequency, expanded.interval) <= 0 {
+			return nil, fmt.Errorf("`rrule` frequency %q is not supported for a maintenance window", expanded.frequency)
+		}
+
+		horizon := start.AddDate(0, 0, expandDays)
+		windowStarts = windowStarts[:0]
+		for next := start; next.Before(horizon); next = logicAppTriggerRecurrenceStepOccurrence(next, expanded.frequency, expanded.interval, loc) {
+			windowStarts = append(windowStarts, next)
+		}
+	}
+
+	// Every occurrence of the window spans `duration`, not an instant - expand each one
+	// across every instant the target trigger actually fires inside it, so a window
+	// longer than the trigger's own interval suppresses all of its firings rather than
+	// just the one at the window's start.
+	cadencePeriod := logicAppFrequencyToDuration(cadence.frequency, cadence.interval)
+
+	firingsPerWindow := 1
+	if cadencePeriod > 0 {
+		firingsPerWindow = int(duration/cadencePeriod) + 1
+	}
+	if total := len(windowStarts) * firingsPerWindow; total > maxLogicAppTriggerRecurrenceMaintenanceWindowOccurrences {
+		return nil, fmt.Errorf("this maintenance window would expand to %d excluded occurrences (%d window occurrence(s) over `expand_occurrences_for_days`, each suppressing up to %d trigger firing(s)), which exceeds the limit of %d - narrow `rrule`, `expand_occurrences_for_days`, or the window's `duration` between `start_time` and `end_time`", total, len(windowStarts), firingsPerWindow, maxLogicAppTriggerRecurrenceMaintenanceWindowOccurrences)
+	}
+
+	occurrences := make([]string, 0, len(windowStarts))
+	for _, occurrenceStart := range windowStarts {
+		if cadencePeriod <= 0 {
+			// the target trigger's own cadence isn't one this package can step through
+			// (e.g. its frequency is missing or unrecognised) - fall back to excluding
+			// just the window's own start instant rather than guessing at its firings.
+			occurrences = append(occurrences, occurrenceStart.Format(time.RFC3339))
+			continue
+		}
+		occurrenceEnd := occurrenceStart.Add(duration)
+		for at := occurrenceStart; at.Before(occurrenceEnd); at = at.Add(cadencePeriod) {
+			occurrences = append(occurrences, at.Format(time.RFC3339))
+		}
+	}
+
+	return occurrences, nil
+}
+
+// maxLogicAppTriggerRecurrenceMaintenanceWindowOccurrences bounds the total number of
+// excluded instants a single maintenance window can expand to. Without a cap, a
+// recurring window spanning many trigger firings (e.g. a 12-hour window on a
+// per-second trigger, expanded across `expand_occurrences_for_days = 90`) can produce
+// millions of entries, which is a silent multi-hundred-MB PATCH rather than a clear
+// validation error.
+const maxLogicAppTriggerRecurrenceMaintenanceWindowOccurrences = 50000
+
+// resolveLogicAppTriggerRecurrenceTimeZoneLocation resolves a maintenance window's
+// `time_zone` (accepted in either the Windows form the API uses or the IANA form, per
+// validateLogicAppTriggerRecurrenceTimeZone) to a *time.Location. An empty `time_zone`
+// resolves to a nil location, meaning "expand using the absolute instants as given".
+func resolveLogicAppTriggerRecurrenceTimeZoneLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if iana, ok := windowsToIANATimeZones[name]; ok {
+		name = iana
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// logicAppTriggerRecurrenceStepOccurrence advances `from` by one occurrence of the
+// given frequency/interval. When `loc` is set and the frequency is calendar-based
+// (Day/Week/Month) the step is taken in that location's wall-clock time, so the
+// occurrence keeps the same local time of day across a DST transition instead of
+// drifting by the transition's offset; every other case steps by a fixed absolute
+// duration, as before.
+func logicAppTriggerRecurrenceStepOccurrence(from time.Time, frequency string, interval int, loc *time.Location) time.Time {
+	if loc != nil {
+		switch frequency {
+		case "Day":
+			return from.In(loc).AddDate(0, 0, interval).UTC()
+		case "Week":
+			return from.In(loc).AddDate(0, 0, 7*interval).UTC()
+		case "Month":
+			return from.In(loc).AddDate(0, interval, 0).UTC()
+		}
+	}
+	return from.Add(logicAppFrequencyToDuration(frequency, interval))
+}
+
+func logicAppFrequencyToDuration(frequency string, interval int) time.Duration {
+	unit := time.Duration(0)
+	switch frequency {
+	case "Month":
+		unit = 30 * 24 * time.Hour
+	case "Week":
+		unit = 7 * 24 * time.Hour
+	case "Day":
+		unit = 24 * time.Hour
+	case "Hour":
+		unit = time.Hour
+	case "Minute":
+		unit = time.Minute
+	case "Second":
+		unit = time.Second
+	}
+	return unit * time.Duration(interval)
+}
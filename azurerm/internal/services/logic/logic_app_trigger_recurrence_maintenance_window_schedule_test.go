@@ -0,0 +1,187 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestApplyLogicAppTriggerRecurrenceMaintenanceWindow(t *testing.T) {
+	// the trigger itself fires hourly, and the window spans 2 hours - every firing
+	// inside the window (02:00 and 03:00), not just the one at `start_time`, must end
+	// up excluded.
+	recurrence := map[string]interface{}{
+		"frequency": "Hour",
+		"interval":  1,
+	}
+	window := logicAppTriggerRecurrenceMaintenanceWindow{
+		startTime: "2026-01-01T02:00:00Z",
+		endTime:   "2026-01-01T04:00:00Z",
+	}
+
+	excluded, err := applyLogicAppTriggerRecurrenceMaintenanceWindow(recurrence, "patch-window", window, "skip", 90)
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+
+	schedule := excluded["schedule"].(map[string]interface{})
+	exceptions := schedule["exceptions"].([]string)
+	if !reflect.DeepEqual(exceptions, []string{"2026-01-01T02:00:00Z", "2026-01-01T03:00:00Z"}) {
+		t.Fatalf("unexpected exceptions: %#v", exceptions)
+	}
+	if _, ok := schedule["excludedDates"]; ok {
+		t.Fatalf("did not expect `excludedDates` to be set for a `skip` action")
+	}
+
+	// the original `recurrence` passed in must not be mutated
+	if _, ok := recurrence["schedule"]; ok {
+		t.Fatalf("applyLogicAppTriggerRecurrenceMaintenanceWindow must not mutate its input")
+	}
+}
+
+func TestExpandLogicAppTriggerRecurrenceMaintenanceWindowOccurrences(t *testing.T) {
+	t.Run("a one-off window excludes every firing between start_time and end_time", func(t *testing.T) {
+		window := logicAppTriggerRecurrenceMaintenanceWindow{
+			startTime: "2026-01-01T02:00:00Z",
+			endTime:   "2026-01-01T05:00:00Z",
+		}
+		cadence := logicAppTriggerRecurrenceCadence{frequency: "Hour", interval: 1}
+
+		occurrences, err := expandLogicAppTriggerRecurrenceMaintenanceWindowOccurrences(window, 90, cadence)
+		if err != nil {
+			t.Fatalf("expected no error, got %+v", err)
+		}
+
+		expected := []string{"2026-01-01T02:00:00Z", "2026-01-01T03:00:00Z", "2026-01-01T04:00:00Z"}
+		if !reflect.DeepEqual(occurrences, expected) {
+			t.Fatalf("expected %#v, got %#v", expected, occurrences)
+		}
+	})
+
+	t.Run("an unrecognised trigger cadence falls back to the window's own start instant", func(t *testing.T) {
+		window := logicAppTriggerRecurrenceMaintenanceWindow{
+			startTime: "2026-01-01T02:00:00Z",
+			endTime:   "2026-01-01T05:00:00Z",
+		}
+		cadence := logicAppTriggerRecurrenceCadence{}
+
+		occurrences, err := expandLogicAppTriggerRecurrenceMaintenanceWindowOccurrences(window, 90, cadence)
+		if err != nil {
+			t.Fatalf("expected no error, got %+v", err)
+		}
+		if !reflect.DeepEqual(occurrences, []string{"2026-01-01T02:00:00Z"}) {
+			t.Fatalf("unexpected occurrences: %#v", occurrences)
+		}
+	})
+
+	t.Run("a `time_zone` keeps a daily window's wall-clock span fixed across a DST transition", func(t *testing.T) {
+		// Europe/London moves clocks forward an hour at 2026-03-29T01:00:00Z. A window
+		// declared as 02:00-03:00 Europe/London local time on the day either side of
+		// that transition must keep expanding to 02:00-03:00 local time, even though
+		// that's 02:00-03:00Z the day before and 01:00-02:00Z the day after.
+		window := logicAppTriggerRecurrenceMaintenanceWindow{
+			startTime: "2026-03-28T02:00:00Z",
+			endTime:   "2026-03-28T03:00:00Z",
+			rrule:     "FREQ=DAILY;INTERVAL=1",
+			timeZone:  "Europe/London",
+		}
+		cadence := logicAppTriggerRecurrenceCadence{frequency: "Hour", interval: 1}
+
+		occurrences, err := expandLogicAppTriggerRecurrenceMaintenanceWindowOccurrences(window, 2, cadence)
+		if err != nil {
+			t.Fatalf("expected no error, got %+v", err)
+		}
+
+		expected := []string{"2026-03-28T02:00:00Z", "2026-03-29T01:00:00Z", "2026-03-30T01:00:00Z"}
+		if !reflect.DeepEqual(occurrences, expected) {
+			t.Fatalf("expected %#v, got %#v", expected, occurrences)
+		}
+	})
+
+	t.Run("an overnight window on a per-second trigger, expanded for 90 days, is rejected instead of silently expanding to millions of entries", func(t *testing.T) {
+		window := logicAppTriggerRecurrenceMaintenanceWindow{
+			startTime: "2026-01-01T22:00:00Z",
+			endTime:   "2026-01-02T10:00:00Z",
+			rrule:     "FREQ=DAILY;INTERVAL=1",
+		}
+		cadence := logicAppTriggerRecurrenceCadence{frequency: "Second", interval: 1}
+
+		if _, err := expandLogicAppTriggerRecurrenceMaintenanceWindowOccurrences(window, 90, cadence); err == nil {
+			t.Fatal("expected an error for an excessive occurrence count, got none")
+		}
+	})
+}
+
+func TestApplyLogicAppTriggerRecurrenceMaintenanceWindowMultipleWindows(t *testing.T) {
+	recurrence := map[string]interface{}{"frequency": "Hour", "interval": 1}
+
+	a := logicAppTriggerRecurrenceMaintenanceWindow{startTime: "2026-01-01T02:00:00Z", endTime: "2026-01-01T03:00:00Z"}
+	withA, err := applyLogicAppTriggerRecurrenceMaintenanceWindow(recurrence, "window-a", a, "disable", 90)
+	if err != nil {
+		t.Fatalf("expected no error applying window-a, got %+v", err)
+	}
+
+	// applying window-b onto the recurrence that already carries window-a's
+	// exclusion, as the create/update path does against the trigger's live recurrence
+	b := logicAppTriggerRecurrenceMaintenanceWindow{startTime: "2026-02-01T02:00:00Z", endTime: "2026-02-01T03:00:00Z"}
+	withBoth, err := applyLogicAppTriggerRecurrenceMaintenanceWindow(withA, "window-b", b, "disable", 90)
+	if err != nil {
+		t.Fatalf("expected no error applying window-b, got %+v", err)
+	}
+
+	schedule := withBoth["schedule"].(map[string]interface{})
+	excludedDates := schedule["excludedDates"].([]string)
+	if len(excludedDates) != 2 {
+		t.Fatalf("expected both windows' exclusions to be present, got %#v", excludedDates)
+	}
+
+	// deleting window-a must leave window-b's exclusion untouched, regardless of order
+	afterRemovingA := removeLogicAppTriggerRecurrenceMaintenanceWindow(withBoth, "window-a")
+	remaining := afterRemovingA["schedule"].(map[string]interface{})["excludedDates"].([]string)
+	if !reflect.DeepEqual(remaining, []string{"2026-02-01T02:00:00Z"}) {
+		t.Fatalf("expected only window-b's exclusion to remain, got %#v", remaining)
+	}
+}
+
+func TestIsLogicAppTriggerRecurrenceMaintenanceWindowActive(t *testing.T) {
+	oneOff := logicAppTriggerRecurrenceMaintenanceWindow{
+		startTime: "2026-01-01T02:00:00Z",
+		endTime:   "2026-01-01T04:00:00Z",
+	}
+	recurring := logicAppTriggerRecurrenceMaintenanceWindow{
+		startTime: "2026-01-01T02:00:00Z",
+		endTime:   "2026-01-01T04:00:00Z",
+		rrule:     "FREQ=DAILY;INTERVAL=1",
+	}
+
+	mustParse := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", value, err)
+		}
+		return parsed
+	}
+
+	cases := []struct {
+		name     string
+		window   logicAppTriggerRecurrenceMaintenanceWindow
+		at       time.Time
+		expected bool
+	}{
+		{"one-off, inside the window", oneOff, mustParse("2026-01-01T03:00:00Z"), true},
+		{"one-off, before the window", oneOff, mustParse("2026-01-01T01:00:00Z"), false},
+		{"one-off, after the window", oneOff, mustParse("2026-01-02T03:00:00Z"), false},
+		{"recurring, inside the first occurrence", recurring, mustParse("2026-01-01T03:00:00Z"), true},
+		{"recurring, inside the third occurrence", recurring, mustParse("2026-01-03T03:00:00Z"), true},
+		{"recurring, between occurrences", recurring, mustParse("2026-01-03T12:00:00Z"), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := isLogicAppTriggerRecurrenceMaintenanceWindowActive(tc.window, tc.at)
+			if actual != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package logic
 import (
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -43,7 +44,8 @@ func resourceLogicAppTriggerRecurrence() *schema.Resource {
 
 			"frequency": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ValidateFunc: validation.StringInSlice([]string{
 					"Month",
 					"Week",
@@ -57,25 +59,30 @@ func resourceLogicAppTriggerRecurrence() *schema.Resource {
 
 			"interval": {
 				Type:     schema.TypeInt,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 
 			"start_time": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.IsRFC3339Time,
 			},
 
 			"schedule": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"rrule"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"at_these_hours": {
 							Type:         schema.TypeSet,
 							Optional:     true,
-							AtLeastOneOf: []string{"schedule.0.at_these_hours", "schedule.0.at_these_minutes", "schedule.0.on_these_days"},
+							Computed:     true,
+							AtLeastOneOf: []string{"schedule.0.at_these_hours", "schedule.0.at_these_minutes", "schedule.0.on_these_days", "schedule.0.on_these_month_days", "schedule.0.monthly_occurrence"},
 							Elem: &schema.Schema{
 								Type:         schema.TypeInt,
 								ValidateFunc: validation.IntBetween(0, 23),
@@ -84,7 +91,8 @@ func resourceLogicAppTriggerRecurrence() *schema.Resource {
 						"at_these_minutes": {
 							Type:         schema.TypeSet,
 							Optional:     true,
-							AtLeastOneOf: []string{"schedule.0.at_these_hours", "schedule.0.at_these_minutes", "schedule.0.on_these_days"},
+							Computed:     true,
+							AtLeastOneOf: []string{"schedule.0.at_these_hours", "schedule.0.at_these_minutes", "schedule.0.on_these_days", "schedule.0.on_these_month_days", "schedule.0.monthly_occurrence"},
 							Elem: &schema.Schema{
 								Type:         schema.TypeInt,
 								ValidateFunc: validation.IntBetween(0, 59),
@@ -93,7 +101,8 @@ func resourceLogicAppTriggerRecurrence() *schema.Resource {
 						"on_these_days": {
 							Type:         schema.TypeSet,
 							Optional:     true,
-							AtLeastOneOf: []string{"schedule.0.at_these_hours", "schedule.0.at_these_minutes", "schedule.0.on_these_days"},
+							Computed:     true,
+							AtLeastOneOf: []string{"schedule.0.at_these_hours", "schedule.0.at_these_minutes", "schedule.0.on_these_days", "schedule.0.on_these_month_days", "schedule.0.monthly_occurrence"},
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 								ValidateFunc: validation.StringInSlice([]string{
@@ -107,10 +116,123 @@ func resourceLogicAppTriggerRecurrence() *schema.Resource {
 								}, false),
 							},
 						},
+
+						// on_these_month_days and monthly_occurrence exist primarily so that a
+						// recurrence configured via `rrule` (BYMONTHDAY / BYSETPOS+BYDAY) can be
+						// round-tripped into `schedule` on read without losing information.
+						"on_these_month_days": {
+							Type:         schema.TypeSet,
+							Optional:     true,
+							Computed:     true,
+							AtLeastOneOf: []string{"schedule.0.at_these_hours", "schedule.0.at_these_minutes", "schedule.0.on_these_days", "schedule.0.on_these_month_days", "schedule.0.monthly_occurrence"},
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(-31, 31),
+							},
+						},
+						"monthly_occurrence": {
+							Type:         schema.TypeList,
+							Optional:     true,
+							Computed:     true,
+							AtLeastOneOf: []string{"schedule.0.at_these_hours", "schedule.0.at_these_minutes", "schedule.0.on_these_days", "schedule.0.on_these_month_days", "schedule.0.monthly_occurrence"},
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"day": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											"Monday",
+											"Tuesday",
+											"Wednesday",
+											"Thursday",
+											"Friday",
+											"Saturday",
+											"Sunday",
+										}, false),
+									},
+									"occurrence": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(-5, 5),
+									},
+								},
+							},
+						},
 					},
 				},
 			},
 
+			"rrule": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"schedule"},
+				ValidateFunc:  validateLogicAppTriggerRecurrenceRRule,
+			},
+
+			// during_business_hours/outside_business_hours are an alternative to
+			// `frequency`/`interval`/`schedule`: rather than a single recurrence, the
+			// provider synthesizes two underlying triggers - one active during the
+			// declared business hours, one outside them - so e.g. a queue can be
+			// polled every 5 minutes during the day and hourly overnight without the
+			// user having to declare and keep two separate resources in sync.
+			"during_business_hours": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				RequiredWith:  []string{"outside_business_hours"},
+				ConflictsWith: []string{"rrule", "schedule", "frequency", "interval", "start_time"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start_time_of_day": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateLogicAppTriggerRecurrenceTimeOfDay,
+						},
+						"end_time_of_day": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateLogicAppTriggerRecurrenceTimeOfDay,
+						},
+						"days_of_week": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									"Monday",
+									"Tuesday",
+									"Wednesday",
+									"Thursday",
+									"Friday",
+									"Saturday",
+									"Sunday",
+								}, false),
+							},
+						},
+						"schedule": logicAppTriggerRecurrenceBusinessHoursScheduleResource(),
+					},
+				},
+			},
+
+			"outside_business_hours": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				RequiredWith:  []string{"during_business_hours"},
+				ConflictsWith: []string{"rrule", "schedule", "frequency", "interval", "start_time"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schedule": logicAppTriggerRecurrenceBusinessHoursScheduleResource(),
+					},
+				},
+			},
+
+			"outside_business_hours_trigger_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"time_zone": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -122,25 +244,58 @@ func resourceLogicAppTriggerRecurrence() *schema.Resource {
 }
 
 func resourceLogicAppTriggerRecurrenceCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	trigger := map[string]interface{}{
-		"recurrence": map[string]interface{}{
-			"frequency": d.Get("frequency").(string),
-			"interval":  d.Get("interval").(int),
-		},
-		"type": "Recurrence",
+	if _, ok := d.GetOk("during_business_hours"); ok {
+		return resourceLogicAppTriggerRecurrenceBusinessHoursCreateUpdate(d, meta)
 	}
 
-	if v, ok := d.GetOk("start_time"); ok {
-		trigger["recurrence"].(map[string]interface{})["startTime"] = v.(string)
+	recurrence := map[string]interface{}{}
+
+	if rruleRaw, ok := d.GetOk("rrule"); ok {
+		expanded, err := expandLogicAppTriggerRecurrenceRRule(rruleRaw.(string))
+		if err != nil {
+			return fmt.Errorf("parsing `rrule`: %+v", err)
+		}
 
-		// time_zone only allowed when start_time is specified
+		recurrence["frequency"] = expanded.frequency
+		recurrence["interval"] = expanded.interval
+		if expanded.startTime != "" {
+			recurrence["startTime"] = expanded.startTime
+		}
+		if len(expanded.schedule) > 0 {
+			recurrence["schedule"] = expanded.schedule
+		}
+	} else {
+		frequency, ok := d.GetOk("frequency")
+		if !ok {
+			return fmt.Errorf("`frequency` is required when `rrule` is not specified")
+		}
+		interval, ok := d.GetOk("interval")
+		if !ok {
+			return fmt.Errorf("`interval` is required when `rrule` is not specified")
+		}
+
+		recurrence["frequency"] = frequency.(string)
+		recurrence["interval"] = interval.(int)
+
+		if v, ok := d.GetOk("start_time"); ok {
+			recurrence["startTime"] = v.(string)
+		}
+
+		if v, ok := d.GetOk("schedule"); ok {
+			recurrence["schedule"] = expandLogicAppTriggerRecurrenceSchedule(v.([]interface{}))
+		}
+	}
+
+	// time_zone only allowed when start_time is specified
+	if _, ok := recurrence["startTime"]; ok {
 		if v, ok := d.GetOk("time_zone"); ok {
-			trigger["recurrence"].(map[string]interface{})["timeZone"] = v.(string)
+			recurrence["timeZone"] = normalizeLogicAppTriggerRecurrenceTimeZone(v.(string))
 		}
 	}
 
-	if v, ok := d.GetOk("schedule"); ok {
-		trigger["recurrence"].(map[string]interface{})["schedule"] = expandLogicAppTriggerRecurrenceSchedule(v.([]interface{}))
+	trigger := map[string]interface{}{
+		"recurrence": recurrence,
+		"type":       "Recurrence",
 	}
 
 	logicAppId := d.Get("logic_app_id").(string)
@@ -153,6 +308,10 @@ func resourceLogicAppTriggerRecurrenceCreateUpdate(d *schema.ResourceData, meta
 }
 
 func resourceLogicAppTriggerRecurrenceRead(d *schema.ResourceData, meta interface{}) error {
+	if isLogicAppTriggerRecurrenceBusinessHoursID(d.Id()) {
+		return resourceLogicAppTriggerRecurrenceBusinessHoursRead(d, meta)
+	}
+
 	id, err := azure.ParseAzureResourceID(d.Id())
 	if err != nil {
 		return err
@@ -201,17 +360,27 @@ func resourceLogicAppTriggerRecurrenceRead(d *schema.ResourceData, meta interfac
 	}
 
 	if timeZone := recurrence["timeZone"]; timeZone != nil {
-		d.Set("time_zone", timeZone.(string))
+		d.Set("time_zone", coerceLogicAppTriggerRecurrenceTimeZone(timeZone.(string), d.Get("time_zone").(string)))
 	}
 
 	if schedule := recurrence["schedule"]; schedule != nil {
 		d.Set("schedule", flattenLogicAppTriggerRecurrenceSchedule(schedule.(map[string]interface{})))
 	}
 
+	// only reconstruct an `rrule` for the user when they're managing the recurrence
+	// that way - otherwise leave `schedule`/`frequency`/`interval` as the source of truth
+	if _, ok := d.GetOk("rrule"); ok {
+		d.Set("rrule", flattenLogicAppTriggerRecurrenceRRule(recurrence))
+	}
+
 	return nil
 }
 
 func resourceLogicAppTriggerRecurrenceDelete(d *schema.ResourceData, meta interface{}) error {
+	if isLogicAppTriggerRecurrenceBusinessHoursID(d.Id()) {
+		return resourceLogicAppTriggerRecurrenceBusinessHoursDelete(d, meta)
+	}
+
 	id, err := azure.ParseAzureResourceID(d.Id())
 	if err != nil {
 		return err
@@ -229,6 +398,125 @@ func resourceLogicAppTriggerRecurrenceDelete(d *schema.ResourceData, meta interf
 	return nil
 }
 
+// IANAToWindowsTimeZones maps commonly used IANA ("Olson") time zone names to the
+// equivalent Microsoft "Windows-style" time zone name expected by the Logic Apps
+// Recurrence API. It's exported so other schedule-related resources in this package
+// (e.g. the maintenance window and rotation resources) can reuse the same mapping.
+//
+// This is not an exhaustive mapping of the IANA database - just the zones that are
+// most likely to be used in practice. See https://support.microsoft.com/en-us/help/973627/microsoft-time-zone-index-values
+// for the canonical list of Windows time zone names.
+var IANAToWindowsTimeZones = map[string]string{
+	"Pacific/Midway":                 "UTC-11",
+	"Pacific/Honolulu":               "Hawaiian Standard Time",
+	"America/Anchorage":              "Alaskan Standard Time",
+	"America/Los_Angeles":            "Pacific Standard Time",
+	"America/Denver":                 "Mountain Standard Time",
+	"America/Phoenix":                "US Mountain Standard Time",
+	"America/Chicago":                "Central Standard Time",
+	"America/Mexico_City":            "Central Standard Time (Mexico)",
+	"America/New_York":               "Eastern Standard Time",
+	"America/Indianapolis":           "US Eastern Standard Time",
+	"America/Halifax":                "Atlantic Standard Time",
+	"America/St_Johns":               "Newfoundland and Labrador Standard Time",
+	"America/Sao_Paulo":              "E South America Standard Time",
+	"America/Argentina/Buenos_Aires": "Argentina Standard Time",
+	"Atlantic/Azores":                "Azores Standard Time",
+	"Europe/London":                  "GMT Standard Time",
+	"Europe/Dublin":                  "Greenwich Standard Time",
+	"Europe/Berlin":                  "W Europe Standard Time",
+	"Europe/Zurich":                  "W Europe Standard Time",
+	"Europe/Paris":                   "Romance Standard Time",
+	"Europe/Warsaw":                  "Central European Standard Time",
+	"Europe/Athens":                  "GTB Standard Time",
+	"Europe/Helsinki":                "FLE Standard Time",
+	"Europe/Istanbul":                "Turkey Standard Time",
+	"Europe/Moscow":                  "Russian Standard Time",
+	"Africa/Cairo":                   "Egypt Standard Time",
+	"Africa/Johannesburg":            "South Africa Standard Time",
+	"Asia/Jerusalem":                 "Israel Standard Time",
+	"Asia/Dubai":                     "Arabian Standard Time",
+	"Asia/Kabul":                     "Afghanistan Standard Time",
+	"Asia/Karachi":                   "Pakistan Standard Time",
+	"Asia/Kolkata":                   "India Standard Time",
+	"Asia/Kathmandu":                 "Nepal Standard Time",
+	"Asia/Dhaka":                     "Central Asia Standard Time",
+	"Asia/Bangkok":                   "SE Asia Standard Time",
+	"Asia/Shanghai":                  "China Standard Time",
+	"Asia/Singapore":                 "Singapore Standard Time",
+	"Asia/Taipei":                    "Taipei Standard Time",
+	"Asia/Tokyo":                     "Tokyo Standard Time",
+	"Asia/Seoul":                     "Korea Standard Time",
+	"Australia/Perth":                "W Australia Standard Time",
+	"Australia/Darwin":               "AUS Central Standard Time",
+	"Australia/Adelaide":             "Cen Australia Standard Time",
+	"Australia/Sydney":               "AUS Eastern Standard Time",
+	"Australia/Brisbane":             "E Australia Standard Time",
+	"Australia/Hobart":               "Tasmania Standard Time",
+	"Pacific/Guadalcanal":            "Central Pacific Standard Time",
+	"Pacific/Fiji":                   "Fiji Islands Standard Time",
+	"Pacific/Auckland":               "New Zealand Standard Time",
+	"Pacific/Tongatapu":              "Tonga Standard Time",
+	"UTC":                            "UTC",
+}
+
+// windowsToIANATimeZones is the inverse of IANAToWindowsTimeZones, used when reading
+// a recurrence back from the API so the value can be coerced to whichever form is
+// currently held in state.
+var windowsToIANATimeZones = func() map[string]string {
+	ianaNames := make([]string, 0, len(IANAToWindowsTimeZones))
+	for iana := range IANAToWindowsTimeZones {
+		ianaNames = append(ianaNames, iana)
+	}
+	// Iterating a map is non-deterministic, so sort the IANA names first - otherwise,
+	// for the Windows zones that multiple IANA names map to, this would have a chance
+	// of picking a different "preferred" IANA name on every process run and flapping
+	// `terraform plan` forever for a recurrence whose time zone was set out-of-band.
+	sort.Strings(ianaNames)
+
+	out := make(map[string]string, len(IANAToWindowsTimeZones))
+	for _, iana := range ianaNames {
+		windows := IANAToWindowsTimeZones[iana]
+		// Where multiple IANA zones map to the same Windows zone, prefer the
+		// alphabetically first one - this is only used as a fallback when state
+		// doesn't already tell us which IANA zone the user configured.
+		if _, exists := out[windows]; !exists {
+			out[windows] = iana
+		}
+	}
+	return out
+}()
+
+// normalizeLogicAppTriggerRecurrenceTimeZone converts an IANA time zone name to its
+// Windows equivalent. Values that are already a Windows time zone name (or aren't
+// present in the mapping table) are returned unchanged.
+func normalizeLogicAppTriggerRecurrenceTimeZone(input string) string {
+	if windows, ok := IANAToWindowsTimeZones[input]; ok {
+		return windows
+	}
+	return input
+}
+
+// coerceLogicAppTriggerRecurrenceTimeZone takes the Windows time zone name returned
+// by the API and coerces it back to whichever form (IANA or Windows) is currently
+// held in the resource's state, so that `terraform plan` remains stable regardless
+// of which form the user originally supplied.
+func coerceLogicAppTriggerRecurrenceTimeZone(apiValue, stateValue string) string {
+	if apiValue == stateValue {
+		return apiValue
+	}
+
+	if normalizeLogicAppTriggerRecurrenceTimeZone(stateValue) == apiValue {
+		return stateValue
+	}
+
+	if iana, ok := windowsToIANATimeZones[apiValue]; ok {
+		return iana
+	}
+
+	return apiValue
+}
+
 func validateLogicAppTriggerRecurrenceTimeZone() schema.SchemaValidateFunc {
 	// from https://support.microsoft.com/en-us/help/973627/microsoft-time-zone-index-values
 	timeZones := []string{
@@ -327,7 +615,13 @@ func validateLogicAppTriggerRecurrenceTimeZone() schema.SchemaValidateFunc {
 		"Paraguay Standard Time",
 		"Kamchatka Standard Time",
 	}
-	return validation.StringInSlice(timeZones, false)
+
+	ianaTimeZones := make([]string, 0, len(IANAToWindowsTimeZones))
+	for iana := range IANAToWindowsTimeZones {
+		ianaTimeZones = append(ianaTimeZones, iana)
+	}
+
+	return validation.StringInSlice(append(timeZones, ianaTimeZones...), false)
 }
 
 func expandLogicAppTriggerRecurrenceSchedule(input []interface{}) map[string]interface{} {
@@ -367,6 +661,30 @@ func expandLogicAppTriggerRecurrenceSchedule(input []interface{}) map[string]int
 			output["weekDays"] = &days
 		}
 	}
+	if monthDaysRaw, ok := attrs["on_these_month_days"]; ok {
+		monthDaysSet := monthDaysRaw.(*schema.Set).List()
+		monthDays := make([]int, 0)
+		for _, monthDay := range monthDaysSet {
+			monthDays = append(monthDays, monthDay.(int))
+		}
+		if len(monthDays) > 0 {
+			output["monthDays"] = &monthDays
+		}
+	}
+	if occurrencesRaw, ok := attrs["monthly_occurrence"]; ok {
+		occurrencesList := occurrencesRaw.([]interface{})
+		occurrences := make([]map[string]interface{}, 0)
+		for _, occurrenceRaw := range occurrencesList {
+			occurrence := occurrenceRaw.(map[string]interface{})
+			occurrences = append(occurrences, map[string]interface{}{
+				"day":        occurrence["day"].(string),
+				"occurrence": occurrence["occurrence"].(int),
+			})
+		}
+		if len(occurrences) > 0 {
+			output["monthlyOccurrences"] = &occurrences
+		}
+	}
 
 	return output
 }
@@ -383,6 +701,21 @@ func flattenLogicAppTriggerRecurrenceSchedule(input map[string]interface{}) []in
 	if days := input["weekDays"]; days != nil {
 		attrs["on_these_days"] = days
 	}
+	if monthDays := input["monthDays"]; monthDays != nil {
+		attrs["on_these_month_days"] = monthDays
+	}
+	if occurrencesRaw := input["monthlyOccurrences"]; occurrencesRaw != nil {
+		occurrencesList := occurrencesRaw.([]interface{})
+		occurrences := make([]interface{}, 0)
+		for _, occurrenceRaw := range occurrencesList {
+			occurrence := occurrenceRaw.(map[string]interface{})
+			occurrences = append(occurrences, map[string]interface{}{
+				"day":        occurrence["day"],
+				"occurrence": int(occurrence["occurrence"].(float64)),
+			})
+		}
+		attrs["monthly_occurrence"] = occurrences
+	}
 
 	return []interface{}{attrs}
 }
@@ -0,0 +1,79 @@
+package logic
+
+import "testing"
+
+func TestCoerceLogicAppTriggerRecurrenceTimeZone(t *testing.T) {
+	cases := []struct {
+		name       string
+		apiValue   string
+		stateValue string
+		expected   string
+	}{
+		{
+			name:       "api value already matches state",
+			apiValue:   "Pacific Standard Time",
+			stateValue: "Pacific Standard Time",
+			expected:   "Pacific Standard Time",
+		},
+		{
+			name:       "state holds the IANA form",
+			apiValue:   "Pacific Standard Time",
+			stateValue: "America/Los_Angeles",
+			expected:   "America/Los_Angeles",
+		},
+		{
+			name:       "state empty - falls back to the IANA form of the API value",
+			apiValue:   "Pacific Standard Time",
+			stateValue: "",
+			expected:   "America/Los_Angeles",
+		},
+		{
+			name:       "no IANA mapping exists - the API value is returned as-is",
+			apiValue:   "Some Unmapped Time Zone",
+			stateValue: "",
+			expected:   "Some Unmapped Time Zone",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := coerceLogicAppTriggerRecurrenceTimeZone(tc.apiValue, tc.stateValue)
+			if actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWindowsToIANATimeZonesIsDeterministic(t *testing.T) {
+	// "Europe/Berlin" and "Europe/Zurich" both map to "W Europe Standard Time" -
+	// windowsToIANATimeZones must resolve that collision the same way every time
+	// `go run`/`go test` builds it, or a recurrence whose time zone was set
+	// out-of-band would never converge in `terraform plan`.
+	const expected = "Europe/Berlin"
+
+	for i := 0; i < 20; i++ {
+		actual := coerceLogicAppTriggerRecurrenceTimeZone("W Europe Standard Time", "")
+		if actual != expected {
+			t.Fatalf("iteration %d: expected %q, got %q", i, expected, actual)
+		}
+	}
+}
+
+func TestNormalizeLogicAppTriggerRecurrenceTimeZone(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "America/Los_Angeles", expected: "Pacific Standard Time"},
+		{input: "UTC", expected: "UTC"},
+		{input: "Pacific Standard Time", expected: "Pacific Standard Time"},
+	}
+
+	for _, tc := range cases {
+		actual := normalizeLogicAppTriggerRecurrenceTimeZone(tc.input)
+		if actual != tc.expected {
+			t.Errorf("normalizeLogicAppTriggerRecurrenceTimeZone(%q): expected %q, got %q", tc.input, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,411 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+// resourceLogicAppTriggerRecurrenceRotation wraps a base recurrence trigger with an
+// ordered set of layers (similar in spirit to an on-call rotation schedule) and keeps
+// the trigger's effective schedule in sync with whichever layer is currently active.
+//
+// Because the underlying trigger has no notion of "layers", each apply computes the
+// single layer active for the current time and writes its schedule to the trigger.
+// `next_rotation_at` tells the caller when to re-apply (e.g. via `time_rotating` or a
+// scheduled `terraform apply`) so the rotation actually advances.
+//
+// Within an active layer, `rotation_turn_length_seconds` divides the layer's window
+// into turns. When `schedule.on_these_days` names more than one day, only one of them
+// is actually live per turn, rotating through the declared days in alphabetical
+// order (not weekday order) - see
+// rotateLogicAppTriggerRecurrenceRotationSchedule. A layer with a single
+// `on_these_days` entry (or none) is unaffected by turns other than via
+// `next_rotation_at`.
+func resourceLogicAppTriggerRecurrenceRotation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLogicAppTriggerRecurrenceRotationCreateUpdate,
+		Read:   resourceLogicAppTriggerRecurrenceRotationRead,
+		Update: resourceLogicAppTriggerRecurrenceRotationCreateUpdate,
+		Delete: resourceLogicAppTriggerRecurrenceRotationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"logic_app_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"trigger_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"layer": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"start": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"end": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"rotation_turn_length_seconds": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(60),
+						},
+						"schedule": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"at_these_hours": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeInt,
+											ValidateFunc: validation.IntBetween(0, 23),
+										},
+									},
+									"at_these_minutes": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeInt,
+											ValidateFunc: validation.IntBetween(0, 59),
+										},
+									},
+									"on_these_days": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												"Monday",
+												"Tuesday",
+												"Wednesday",
+												"Thursday",
+												"Friday",
+												"Saturday",
+												"Sunday",
+											}, false),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"active_layer": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"next_rotation_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceLogicAppTriggerRecurrenceRotationCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	layers, err := expandLogicAppTriggerRecurrenceRotationLayers(d.Get("layer").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	if err := validateLogicAppTriggerRecurrenceRotationLayers(layers); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	active, next, turnIndex := activeLogicAppTriggerRecurrenceRotationLayer(layers, now)
+	if active == nil {
+		return fmt.Errorf("no `layer` is active at %s - `layer` blocks must cover the current time", now.Format(time.RFC3339))
+	}
+
+	logicAppId := d.Get("logic_app_id").(string)
+	triggerName := d.Get("trigger_name").(string)
+
+	id, err := azure.ParseAzureResourceID(logicAppId)
+	if err != nil {
+		return err
+	}
+
+	t, app, err := retrieveLogicAppTrigger(d, meta, id.ResourceGroup, id.Path["workflows"], triggerName)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return fmt.Errorf("Trigger %q was not found in Logic App %q (Resource Group %q)", triggerName, id.Path["workflows"], id.ResourceGroup)
+	}
+	trigger := *t
+
+	recurrence, ok := trigger["recurrence"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("`azurerm_logic_app_trigger_recurrence_rotation` can only target a Recurrence trigger, but %q was not one", triggerName)
+	}
+	recurrence["schedule"] = expandLogicAppTriggerRecurrenceSchedule(rotateLogicAppTriggerRecurrenceRotationSchedule(active.schedule, turnIndex))
+	trigger["recurrence"] = recurrence
+
+	if err := resourceLogicAppTriggerUpdate(d, meta, logicAppId, triggerName, trigger, "azurerm_logic_app_trigger_recurrence_rotation"); err != nil {
+		return err
+	}
+
+	d.Set("active_layer", active.name)
+	d.Set("next_rotation_at", next.Format(time.RFC3339))
+
+	d.SetId(fmt.Sprintf("%s/triggers/%s/rotations/%s", app.ID, triggerName, d.Get("name").(string)))
+
+	return resourceLogicAppTriggerRecurrenceRotationRead(d, meta)
+}
+
+func resourceLogicAppTriggerRecurrenceRotationRead(d *schema.ResourceData, meta interface{}) error {
+	id, err := azure.ParseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := id.ResourceGroup
+	logicAppName := id.Path["workflows"]
+	triggerName := id.Path["triggers"]
+	name := id.Path["rotations"]
+
+	t, app, err := retrieveLogicAppTrigger(d, meta, resourceGroup, logicAppName, triggerName)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		log.Printf("[DEBUG] Trigger %q (Logic App %q / Resource Group %q) was not found - removing Rotation from state", triggerName, logicAppName, resourceGroup)
+		d.SetId("")
+		return nil
+	}
+	trigger := *t
+
+	d.Set("name", name)
+	d.Set("logic_app_id", app.ID)
+	d.Set("trigger_name", triggerName)
+
+	layers, err := expandLogicAppTriggerRecurrenceRotationLayers(d.Get("layer").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	active, next, turnIndex := activeLogicAppTriggerRecurrenceRotationLayer(layers, time.Now())
+	if active == nil {
+		return nil
+	}
+	d.Set("active_layer", active.name)
+	d.Set("next_rotation_at", next.Format(time.RFC3339))
+
+	recurrence, ok := trigger["recurrence"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	serverSchedule, _ := recurrence["schedule"].(map[string]interface{})
+	expectedSchedule := expandLogicAppTriggerRecurrenceSchedule(rotateLogicAppTriggerRecurrenceRotationSchedule(active.schedule, turnIndex))
+
+	if !logicAppTriggerRecurrenceSchedulesEqual(serverSchedule, expectedSchedule) {
+		// drift: the server's schedule no longer matches the active layer's schedule -
+		// clearing active_layer causes a diff on the next plan
+		d.Set("active_layer", "")
+	}
+
+	return nil
+}
+
+func resourceLogicAppTriggerRecurrenceRotationDelete(d *schema.ResourceData, meta interface{}) error {
+	// there's nothing meaningful to revert to - the underlying trigger's schedule is
+	// left as-is, matching whichever layer was active at the time of deletion
+	return nil
+}
+
+type logicAppTriggerRecurrenceRotationLayer struct {
+	name                      string
+	start                     time.Time
+	end                       time.Time
+	rotationTurnLengthSeconds int
+	schedule                  []interface{}
+}
+
+func expandLogicAppTriggerRecurrenceRotationLayers(input []interface{}) ([]logicAppTriggerRecurrenceRotationLayer, error) {
+	layers := make([]logicAppTriggerRecurrenceRotationLayer, 0, len(input))
+
+	for _, raw := range input {
+		attrs := raw.(map[string]interface{})
+
+		start, err := time.Parse(time.RFC3339, attrs["start"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("parsing `layer.start`: %+v", err)
+		}
+		end, err := time.Parse(time.RFC3339, attrs["end"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("parsing `layer.end`: %+v", err)
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("`layer.end` must be after `layer.start` for layer %q", attrs["name"].(string))
+		}
+
+		layers = append(layers, logicAppTriggerRecurrenceRotationLayer{
+			name:                      attrs["name"].(string),
+			start:                     start,
+			end:                       end,
+			rotationTurnLengthSeconds: attrs["rotation_turn_length_seconds"].(int),
+			schedule:                  attrs["schedule"].([]interface{}),
+		})
+	}
+
+	return layers, nil
+}
+
+// validateLogicAppTriggerRecurrenceRotationLayers rejects layer sets in which any two
+// layers' [start, end) time ranges overlap, so at most one layer is ever active at a
+// given time.
+func validateLogicAppTriggerRecurrenceRotationLayers(layers []logicAppTriggerRecurrenceRotationLayer) error {
+	sorted := make([]logicAppTriggerRecurrenceRotationLayer, len(layers))
+	copy(sorted, layers)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start.Before(sorted[j].start) })
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].start.Before(sorted[i-1].end) {
+			return fmt.Errorf("layer %q (%s - %s) overlaps layer %q (%s - %s) - layer windows must not overlap",
+				sorted[i].name, sorted[i].start.Format(time.RFC3339), sorted[i].end.Format(time.RFC3339),
+				sorted[i-1].name, sorted[i-1].start.Format(time.RFC3339), sorted[i-1].end.Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// activeLogicAppTriggerRecurrenceRotationLayer returns the first declared layer whose
+// [start, end) window contains `at`, the time that layer's current turn ends (or, if
+// `at` falls outside every layer, the earliest upcoming layer start), and the
+// zero-based index of the current turn within the layer (floor((at-start)/turnLength)).
+// The turn index feeds rotateLogicAppTriggerRecurrenceRotationSchedule so that
+// `rotation_turn_length_seconds` actually changes what gets applied from one turn to
+// the next, rather than only perturbing `next_rotation_at`.
+func activeLogicAppTriggerRecurrenceRotationLayer(layers []logicAppTriggerRecurrenceRotationLayer, at time.Time) (*logicAppTriggerRecurrenceRotationLayer, time.Time, int) {
+	var next time.Time
+
+	for i := range layers {
+		layer := layers[i]
+		if (at.Equal(layer.start) || at.After(layer.start)) && at.Before(layer.end) {
+			turnLength := time.Duration(layer.rotationTurnLengthSeconds) * time.Second
+			turnIndex := int(at.Sub(layer.start) / turnLength)
+			nextTurn := layer.start.Add(time.Duration(turnIndex+1) * turnLength)
+			if nextTurn.After(layer.end) {
+				nextTurn = layer.end
+			}
+			return &layer, nextTurn, turnIndex
+		}
+
+		if layer.start.After(at) && (next.IsZero() || layer.start.Before(next)) {
+			next = layer.start
+		}
+	}
+
+	return nil, next, 0
+}
+
+// rotateLogicAppTriggerRecurrenceRotationSchedule returns the schedule actually
+// applied for a given turn. When `on_these_days` names more than one day, only
+// `turnIndex`'s day (the declared days taken in sorted order, cycling around) is kept
+// - this is what gives `rotation_turn_length_seconds` a real effect on the trigger
+// rather than leaving every turn identical. Schedules with zero or one declared day
+// are returned unchanged.
+func rotateLogicAppTriggerRecurrenceRotationSchedule(schedule []interface{}, turnIndex int) []interface{} {
+	if len(schedule) != 1 || schedule[0] == nil {
+		return schedule
+	}
+	attrs, ok := schedule[0].(map[string]interface{})
+	if !ok {
+		return schedule
+	}
+	daysSet, ok := attrs["on_these_days"].(*schema.Set)
+	if !ok {
+		return schedule
+	}
+	daysRaw := daysSet.List()
+	if len(daysRaw) <= 1 {
+		return schedule
+	}
+
+	days := make([]string, 0, len(daysRaw))
+	for _, day := range daysRaw {
+		days = append(days, day.(string))
+	}
+	sort.Strings(days)
+
+	turn := turnIndex % len(days)
+	if turn < 0 {
+		turn += len(days)
+	}
+
+	rotated := make(map[string]interface{}, len(attrs))
+	for k, v := range attrs {
+		rotated[k] = v
+	}
+	rotated["on_these_days"] = schema.NewSet(schema.HashString, []interface{}{days[turn]})
+
+	return []interface{}{rotated}
+}
+
+// logicAppTriggerRecurrenceSchedulesEqual compares two schedule payloads for
+// equivalence. Both sides are marshaled to JSON before comparing rather than using
+// `fmt.Sprintf("%v", ...)` on the raw values, because `expandLogicAppTriggerRecurrenceSchedule`
+// stores `hours`/`minutes`/`weekDays`/`monthDays`/`monthlyOccurrences` as pointers
+// (e.g. `*[]int`), which `%v` renders as `&[9]`, while the server's decoded JSON
+// response holds the plain `[]interface{}{9}` - marshaling both sides to JSON
+// normalizes that difference away.
+func logicAppTriggerRecurrenceSchedulesEqual(a, b map[string]interface{}) bool {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return string(aJSON) == string(bJSON)
+}
@@ -0,0 +1,148 @@
+package logic
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func mustParseRFC3339(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("parsing %q: %+v", value, err)
+	}
+	return parsed
+}
+
+func TestActiveLogicAppTriggerRecurrenceRotationLayer(t *testing.T) {
+	layers := []logicAppTriggerRecurrenceRotationLayer{
+		{
+			name:                      "primary",
+			start:                     mustParseRFC3339(t, "2026-01-01T00:00:00Z"),
+			end:                       mustParseRFC3339(t, "2026-01-08T00:00:00Z"),
+			rotationTurnLengthSeconds: 86400,
+		},
+		{
+			name:                      "secondary",
+			start:                     mustParseRFC3339(t, "2026-01-08T00:00:00Z"),
+			end:                       mustParseRFC3339(t, "2026-01-15T00:00:00Z"),
+			rotationTurnLengthSeconds: 86400,
+		},
+	}
+
+	t.Run("within the first layer, first turn", func(t *testing.T) {
+		active, next, turnIndex := activeLogicAppTriggerRecurrenceRotationLayer(layers, mustParseRFC3339(t, "2026-01-01T12:00:00Z"))
+		if active == nil || active.name != "primary" {
+			t.Fatalf("expected `primary` to be active, got %#v", active)
+		}
+		if turnIndex != 0 {
+			t.Errorf("expected turn index 0, got %d", turnIndex)
+		}
+		if !next.Equal(mustParseRFC3339(t, "2026-01-02T00:00:00Z")) {
+			t.Errorf("expected next turn at 2026-01-02T00:00:00Z, got %s", next.Format(time.RFC3339))
+		}
+	})
+
+	t.Run("within the first layer, third turn", func(t *testing.T) {
+		active, _, turnIndex := activeLogicAppTriggerRecurrenceRotationLayer(layers, mustParseRFC3339(t, "2026-01-03T06:00:00Z"))
+		if active == nil || active.name != "primary" {
+			t.Fatalf("expected `primary` to be active, got %#v", active)
+		}
+		if turnIndex != 2 {
+			t.Errorf("expected turn index 2, got %d", turnIndex)
+		}
+	})
+
+	t.Run("before every layer", func(t *testing.T) {
+		active, next, _ := activeLogicAppTriggerRecurrenceRotationLayer(layers, mustParseRFC3339(t, "2025-12-25T00:00:00Z"))
+		if active != nil {
+			t.Fatalf("expected no active layer, got %#v", active)
+		}
+		if !next.Equal(layers[0].start) {
+			t.Errorf("expected next to be the first layer's start, got %s", next.Format(time.RFC3339))
+		}
+	})
+
+	t.Run("after every layer", func(t *testing.T) {
+		active, next, _ := activeLogicAppTriggerRecurrenceRotationLayer(layers, mustParseRFC3339(t, "2026-02-01T00:00:00Z"))
+		if active != nil {
+			t.Fatalf("expected no active layer, got %#v", active)
+		}
+		if !next.IsZero() {
+			t.Errorf("expected a zero `next` once every layer is in the past, got %s", next.Format(time.RFC3339))
+		}
+	})
+}
+
+func TestRotateLogicAppTriggerRecurrenceRotationSchedule(t *testing.T) {
+	schedule := []interface{}{
+		map[string]interface{}{
+			"on_these_days": schema.NewSet(schema.HashString, []interface{}{"Monday", "Tuesday", "Wednesday"}),
+		},
+	}
+
+	cases := []struct {
+		turnIndex int
+		expected  string
+	}{
+		{turnIndex: 0, expected: "Monday"},
+		{turnIndex: 1, expected: "Tuesday"},
+		{turnIndex: 2, expected: "Wednesday"},
+		{turnIndex: 3, expected: "Monday"},
+	}
+
+	for _, tc := range cases {
+		rotated := rotateLogicAppTriggerRecurrenceRotationSchedule(schedule, tc.turnIndex)
+		days := rotated[0].(map[string]interface{})["on_these_days"].(*schema.Set).List()
+		if len(days) != 1 || days[0] != tc.expected {
+			t.Errorf("turn %d: expected only %q, got %#v", tc.turnIndex, tc.expected, days)
+		}
+	}
+
+	t.Run("a single declared day is left unchanged", func(t *testing.T) {
+		single := []interface{}{
+			map[string]interface{}{
+				"on_these_days": schema.NewSet(schema.HashString, []interface{}{"Friday"}),
+			},
+		}
+		rotated := rotateLogicAppTriggerRecurrenceRotationSchedule(single, 5)
+		days := rotated[0].(map[string]interface{})["on_these_days"].(*schema.Set).List()
+		if len(days) != 1 || days[0] != "Friday" {
+			t.Errorf("expected the single day to be unchanged, got %#v", days)
+		}
+	})
+}
+
+func TestLogicAppTriggerRecurrenceSchedulesEqual(t *testing.T) {
+	t.Run("pointer-backed expanded schedule equals its API-decoded JSON round-trip", func(t *testing.T) {
+		hours := []int{9}
+		expanded := map[string]interface{}{
+			"hours": &hours,
+		}
+
+		raw, err := json.Marshal(expanded)
+		if err != nil {
+			t.Fatalf("marshaling expanded schedule: %+v", err)
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			t.Fatalf("unmarshaling expanded schedule: %+v", err)
+		}
+
+		if !logicAppTriggerRecurrenceSchedulesEqual(expanded, decoded) {
+			t.Errorf("expected %#v and its decoded round-trip %#v to compare equal", expanded, decoded)
+		}
+	})
+
+	t.Run("genuinely different schedules are not equal", func(t *testing.T) {
+		a := map[string]interface{}{"hours": &[]int{9}}
+		b := map[string]interface{}{"hours": &[]int{17}}
+
+		if logicAppTriggerRecurrenceSchedulesEqual(a, b) {
+			t.Errorf("expected %#v and %#v to differ", a, b)
+		}
+	})
+}
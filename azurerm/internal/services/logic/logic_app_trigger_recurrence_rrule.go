@@ -0,0 +1,325 @@
+package logic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// rruleWeekdayNamesByIndex is indexed by rrule.Weekday.Day() (0 for Monday ... 6 for
+// Sunday). It's keyed by index rather than by rrule.Weekday value because a
+// rrule.Weekday carries its ordinal (e.g. the "2" in `2TU`) as part of the struct, so
+// `2TU` and a bare `TU` don't compare equal even though they're the same day.
+var rruleWeekdayNamesByIndex = []string{
+	"Monday",
+	"Tuesday",
+	"Wednesday",
+	"Thursday",
+	"Friday",
+	"Saturday",
+	"Sunday",
+}
+
+var rruleWeekdayByName = map[string]rrule.Weekday{
+	"Monday":    rrule.MO,
+	"Tuesday":   rrule.TU,
+	"Wednesday": rrule.WE,
+	"Thursday":  rrule.TH,
+	"Friday":    rrule.FR,
+	"Saturday":  rrule.SA,
+	"Sunday":    rrule.SU,
+}
+
+var rruleFrequencyToLogicAppFrequency = map[rrule.Frequency]string{
+	rrule.MONTHLY:  "Month",
+	rrule.WEEKLY:   "Week",
+	rrule.DAILY:    "Day",
+	rrule.HOURLY:   "Hour",
+	rrule.MINUTELY: "Minute",
+	rrule.SECONDLY: "Second",
+}
+
+var logicAppFrequencyToRRuleFrequency = map[string]rrule.Frequency{
+	"Month":  rrule.MONTHLY,
+	"Week":   rrule.WEEKLY,
+	"Day":    rrule.DAILY,
+	"Hour":   rrule.HOURLY,
+	"Minute": rrule.MINUTELY,
+	"Second": rrule.SECONDLY,
+}
+
+// expandedLogicAppTriggerRecurrenceRRule is the result of translating an RFC 5545
+// RRULE string into the equivalent pieces of a Logic App recurrence payload.
+type expandedLogicAppTriggerRecurrenceRRule struct {
+	frequency string
+	interval  int
+	startTime string
+	schedule  map[string]interface{}
+}
+
+// validateLogicAppTriggerRecurrenceRRule is a schema.SchemaValidateFunc wrapper
+// around parsing the RRULE, so invalid/unsupported expressions are caught at plan
+// time rather than surfacing as an opaque API error.
+func validateLogicAppTriggerRecurrenceRRule(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+
+	if _, err := expandLogicAppTriggerRecurrenceRRule(v); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid RRULE: %+v", k, err))
+	}
+
+	return warnings, errors
+}
+
+func expandLogicAppTriggerRecurrenceRRule(input string) (*expandedLogicAppTriggerRecurrenceRRule, error) {
+	option, err := rrule.StrToROption(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing RRULE: %+v", err)
+	}
+
+	if option.Count > 0 {
+		return nil, fmt.Errorf("`COUNT` is not supported - Logic App recurrences run indefinitely")
+	}
+	if !option.Until.IsZero() {
+		return nil, fmt.Errorf("`UNTIL` is not supported by the Logic Apps recurrence schedule")
+	}
+
+	frequency, ok := rruleFrequencyToLogicAppFrequency[option.Freq]
+	if !ok {
+		return nil, fmt.Errorf("`FREQ=%s` is not supported - must be one of MONTHLY, WEEKLY, DAILY, HOURLY, MINUTELY or SECONDLY", rruleFreqToString(option.Freq))
+	}
+
+	interval := option.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	result := &expandedLogicAppTriggerRecurrenceRRule{
+		frequency: frequency,
+		interval:  interval,
+		schedule:  map[string]interface{}{},
+	}
+
+	if !option.Dtstart.IsZero() {
+		result.startTime = option.Dtstart.Format(time.RFC3339)
+	}
+
+	if len(option.Byhour) > 0 {
+		hours := make([]int, len(option.Byhour))
+		copy(hours, option.Byhour)
+		result.schedule["hours"] = &hours
+	}
+
+	if len(option.Byminute) > 0 {
+		minutes := make([]int, len(option.Byminute))
+		copy(minutes, option.Byminute)
+		result.schedule["minutes"] = &minutes
+	}
+
+	if len(option.Byweekday) > 0 {
+		days := make([]string, 0, len(option.Byweekday))
+		occurrences := make([]map[string]interface{}, 0, len(option.Byweekday))
+
+		for i := range option.Byweekday {
+			weekday := option.Byweekday[i]
+			index := weekday.Day()
+			if index < 0 || index >= len(rruleWeekdayNamesByIndex) {
+				return nil, fmt.Errorf("unsupported `BYDAY` value in RRULE")
+			}
+			name := rruleWeekdayNamesByIndex[index]
+
+			switch {
+			case weekday.N() != 0:
+				// e.g. `FREQ=MONTHLY;BYDAY=2TU` ("second Tuesday of every month") - the
+				// ordinal is encoded on the weekday itself, not via a separate BYSETPOS
+				occurrences = append(occurrences, map[string]interface{}{
+					"day":        name,
+					"occurrence": weekday.N(),
+				})
+			case len(option.Bysetpos) > 0:
+				// e.g. `FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1` ("last weekday of
+				// the month") - the ordinal applies across the whole BYDAY set
+				for _, pos := range option.Bysetpos {
+					occurrences = append(occurrences, map[string]interface{}{
+						"day":        name,
+						"occurrence": pos,
+					})
+				}
+			default:
+				days = append(days, name)
+			}
+		}
+
+		if len(occurrences) > 0 {
+			result.schedule["monthlyOccurrences"] = &occurrences
+		}
+		if len(days) > 0 {
+			result.schedule["weekDays"] = &days
+		}
+	}
+
+	if len(option.Bymonthday) > 0 {
+		monthDays := make([]int, len(option.Bymonthday))
+		copy(monthDays, option.Bymonthday)
+		result.schedule["monthDays"] = &monthDays
+	}
+
+	return result, nil
+}
+
+func rruleFreqToString(freq rrule.Frequency) string {
+	for name, f := range logicAppFrequencyToRRuleFrequency {
+		if f == freq {
+			return name
+		}
+	}
+	return "UNKNOWN"
+}
+
+// flattenLogicAppTriggerRecurrenceRRule reconstructs a canonical RRULE string from a
+// recurrence payload for a resource that's managed via `rrule`. The result is compared
+// with the configured `rrule` as plain text, so `terraform plan` is only clean when the
+// configuration is already written in this canonical form: an equivalent RRULE with a
+// different component order, an explicit `INTERVAL=1`, or an ordinal written as
+// `BYDAY=2TU` (rebuilt as `BYDAY=TU;BYSETPOS=2`) shows as a change. Where several
+// `monthlyOccurrences` share an ordinal, that ordinal is repeated once per entry in
+// `BYSETPOS`.
+func flattenLogicAppTriggerRecurrenceRRule(recurrence map[string]interface{}) string {
+	parts := make([]string, 0)
+
+	if startTime, ok := recurrence["startTime"].(string); ok && startTime != "" {
+		if parsed, err := time.Parse(time.RFC3339, startTime); err == nil {
+			parts = append(parts, fmt.Sprintf("DTSTART=%s", parsed.UTC().Format("20060102T150405Z")))
+		}
+	}
+
+	if frequency, ok := recurrence["frequency"].(string); ok {
+		if freq, ok := logicAppFrequencyToRRuleFrequency[frequency]; ok {
+			parts = append(parts, fmt.Sprintf("FREQ=%s", strings.ToUpper(rruleFreqName(freq))))
+		}
+	}
+
+	if interval, ok := recurrence["interval"].(float64); ok && int(interval) > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", int(interval)))
+	}
+
+	schedule, _ := recurrence["schedule"].(map[string]interface{})
+
+	if hoursRaw, ok := schedule["hours"]; ok {
+		parts = append(parts, fmt.Sprintf("BYHOUR=%s", joinFloatInterfaceSlice(hoursRaw)))
+	}
+	if minutesRaw, ok := schedule["minutes"]; ok {
+		parts = append(parts, fmt.Sprintf("BYMINUTE=%s", joinFloatInterfaceSlice(minutesRaw)))
+	}
+	if daysRaw, ok := schedule["weekDays"]; ok {
+		parts = append(parts, fmt.Sprintf("BYDAY=%s", joinWeekdayNames(daysRaw)))
+	}
+	if monthDaysRaw, ok := schedule["monthDays"]; ok {
+		parts = append(parts, fmt.Sprintf("BYMONTHDAY=%s", joinFloatInterfaceSlice(monthDaysRaw)))
+	}
+	if occurrencesRaw, ok := schedule["monthlyOccurrences"]; ok {
+		if occurrences, ok := occurrencesRaw.([]interface{}); ok {
+			days := make([]string, 0, len(occurrences))
+			positions := make([]string, 0, len(occurrences))
+			for _, occurrenceRaw := range occurrences {
+				occurrence, ok := occurrenceRaw.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if day, ok := occurrence["day"].(string); ok {
+					days = append(days, rruleWeekdayAbbreviation(day))
+				}
+				if pos, ok := occurrence["occurrence"].(float64); ok {
+					positions = append(positions, fmt.Sprintf("%d", int(pos)))
+				}
+			}
+			if len(days) > 0 {
+				parts = append(parts, fmt.Sprintf("BYDAY=%s", strings.Join(days, ",")))
+			}
+			if len(positions) > 0 {
+				sort.Strings(positions)
+				parts = append(parts, fmt.Sprintf("BYSETPOS=%s", strings.Join(positions, ",")))
+			}
+		}
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func rruleFreqName(freq rrule.Frequency) string {
+	switch freq {
+	case rrule.MONTHLY:
+		return "MONTHLY"
+	case rrule.WEEKLY:
+		return "WEEKLY"
+	case rrule.DAILY:
+		return "DAILY"
+	case rrule.HOURLY:
+		return "HOURLY"
+	case rrule.MINUTELY:
+		return "MINUTELY"
+	case rrule.SECONDLY:
+		return "SECONDLY"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func rruleWeekdayAbbreviation(day string) string {
+	weekday, ok := rruleWeekdayByName[day]
+	if !ok {
+		return ""
+	}
+	switch weekday {
+	case rrule.MO:
+		return "MO"
+	case rrule.TU:
+		return "TU"
+	case rrule.WE:
+		return "WE"
+	case rrule.TH:
+		return "TH"
+	case rrule.FR:
+		return "FR"
+	case rrule.SA:
+		return "SA"
+	case rrule.SU:
+		return "SU"
+	default:
+		return ""
+	}
+}
+
+func joinFloatInterfaceSlice(input interface{}) string {
+	values, ok := input.([]interface{})
+	if !ok {
+		return ""
+	}
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if f, ok := v.(float64); ok {
+			parts = append(parts, fmt.Sprintf("%d", int(f)))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func joinWeekdayNames(input interface{}) string {
+	values, ok := input.([]interface{})
+	if !ok {
+		return ""
+	}
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if day, ok := v.(string); ok {
+			parts = append(parts, rruleWeekdayAbbreviation(day))
+		}
+	}
+	return strings.Join(parts, ",")
+}
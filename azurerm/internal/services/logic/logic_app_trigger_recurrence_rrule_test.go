@@ -0,0 +1,115 @@
+package logic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandLogicAppTriggerRecurrenceRRule(t *testing.T) {
+	t.Run("FREQ=MONTHLY;BYDAY=2TU - second Tuesday of the month", func(t *testing.T) {
+		result, err := expandLogicAppTriggerRecurrenceRRule("FREQ=MONTHLY;BYDAY=2TU")
+		if err != nil {
+			t.Fatalf("expected no error, got %+v", err)
+		}
+		if result.frequency != "Month" {
+			t.Errorf("expected frequency %q, got %q", "Month", result.frequency)
+		}
+
+		occurrencesRaw, ok := result.schedule["monthlyOccurrences"].(*[]map[string]interface{})
+		if !ok {
+			t.Fatalf("expected `monthlyOccurrences` to be set, got %#v", result.schedule)
+		}
+		occurrences := *occurrencesRaw
+		if len(occurrences) != 1 {
+			t.Fatalf("expected exactly one occurrence, got %d", len(occurrences))
+		}
+		if occurrences[0]["day"] != "Tuesday" || occurrences[0]["occurrence"] != 2 {
+			t.Errorf("expected {day: Tuesday, occurrence: 2}, got %#v", occurrences[0])
+		}
+		if _, ok := result.schedule["weekDays"]; ok {
+			t.Errorf("did not expect `weekDays` to be set alongside a BYDAY ordinal")
+		}
+	})
+
+	t.Run("FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1 - last weekday of the month", func(t *testing.T) {
+		result, err := expandLogicAppTriggerRecurrenceRRule("FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1")
+		if err != nil {
+			t.Fatalf("expected no error, got %+v", err)
+		}
+
+		occurrencesRaw, ok := result.schedule["monthlyOccurrences"].(*[]map[string]interface{})
+		if !ok {
+			t.Fatalf("expected `monthlyOccurrences` to be set, got %#v", result.schedule)
+		}
+		occurrences := *occurrencesRaw
+		if len(occurrences) != 5 {
+			t.Fatalf("expected one occurrence per weekday, got %d", len(occurrences))
+		}
+		for _, occurrence := range occurrences {
+			if occurrence["occurrence"] != -1 {
+				t.Errorf("expected every occurrence to carry BYSETPOS=-1, got %#v", occurrence)
+			}
+		}
+	})
+
+	t.Run("FREQ=WEEKLY;BYDAY=MO,WE,FR - plain weekday set, no ordinal", func(t *testing.T) {
+		result, err := expandLogicAppTriggerRecurrenceRRule("FREQ=WEEKLY;BYDAY=MO,WE,FR")
+		if err != nil {
+			t.Fatalf("expected no error, got %+v", err)
+		}
+
+		daysRaw, ok := result.schedule["weekDays"].(*[]string)
+		if !ok {
+			t.Fatalf("expected `weekDays` to be set, got %#v", result.schedule)
+		}
+		if len(*daysRaw) != 3 {
+			t.Fatalf("expected 3 week days, got %d", len(*daysRaw))
+		}
+		if _, ok := result.schedule["monthlyOccurrences"]; ok {
+			t.Errorf("did not expect `monthlyOccurrences` to be set for a plain BYDAY set")
+		}
+	})
+
+	t.Run("FREQ=MONTHLY;COUNT=5 - COUNT is not supported", func(t *testing.T) {
+		if _, err := expandLogicAppTriggerRecurrenceRRule("FREQ=MONTHLY;COUNT=5"); err == nil {
+			t.Fatal("expected an error for COUNT, got none")
+		}
+	})
+
+	t.Run("FREQ=YEARLY is not supported", func(t *testing.T) {
+		if _, err := expandLogicAppTriggerRecurrenceRRule("FREQ=YEARLY"); err == nil {
+			t.Fatal("expected an error for an unsupported FREQ, got none")
+		}
+	})
+}
+
+func TestFlattenLogicAppTriggerRecurrenceRRule(t *testing.T) {
+	t.Run("startTime round-trips as DTSTART", func(t *testing.T) {
+		recurrence := map[string]interface{}{
+			"frequency": "Week",
+			"startTime": "2024-01-02T15:04:05Z",
+			"schedule": map[string]interface{}{
+				"weekDays": []interface{}{"Monday"},
+			},
+		}
+
+		result := flattenLogicAppTriggerRecurrenceRRule(recurrence)
+		if !strings.Contains(result, "DTSTART=20240102T150405Z") {
+			t.Errorf("expected %q to contain the original DTSTART, got %q", result, "DTSTART=20240102T150405Z")
+		}
+	})
+
+	t.Run("no startTime - no DTSTART emitted", func(t *testing.T) {
+		recurrence := map[string]interface{}{
+			"frequency": "Week",
+			"schedule": map[string]interface{}{
+				"weekDays": []interface{}{"Monday"},
+			},
+		}
+
+		result := flattenLogicAppTriggerRecurrenceRRule(recurrence)
+		if strings.Contains(result, "DTSTART") {
+			t.Errorf("did not expect a DTSTART component, got %q", result)
+		}
+	})
+}